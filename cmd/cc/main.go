@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/christopher.carver/cc/internal/config"
 	"github.com/christopher.carver/cc/internal/explain"
 	"github.com/christopher.carver/cc/internal/git"
 	"github.com/christopher.carver/cc/internal/setup"
+	"github.com/christopher.carver/cc/internal/shell"
 	"github.com/christopher.carver/cc/internal/terraform"
 	ufcli "github.com/urfave/cli/v2"
 )
@@ -15,14 +17,50 @@ import (
 func main() {
 	ctx := context.Background()
 
+	var timings *shell.Timings
+
 	app := &ufcli.App{
 		Name:  "cc",
 		Usage: "Development and SRE-based CLI tooling - turning cc commands into shortcuts for git and terraform interaction ",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "timings",
+				Usage: "Print how long each external command took when done",
+			},
+			&ufcli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the commands and file writes that would change state instead of performing them",
+			},
+			&ufcli.BoolFlag{
+				Name:    "verbose",
+				Aliases: []string{"v"},
+				Usage:   "Log each external command, its directory, and how long it took to stderr",
+			},
+		},
+		Before: func(c *ufcli.Context) error {
+			if c.Bool("dry-run") {
+				c.Context = shell.WithDryRun(c.Context)
+			}
+			if c.Bool("verbose") {
+				c.Context = shell.WithVerbose(c.Context)
+			}
+			if c.Bool("timings") {
+				c.Context, timings = shell.WithTimings(c.Context)
+			}
+			return nil
+		},
+		After: func(c *ufcli.Context) error {
+			if timings != nil {
+				timings.Print(os.Stderr)
+			}
+			return nil
+		},
 		Commands: []*ufcli.Command{
 			setup.NewSetupCmd(),
 			git.NewGitCmd(),
 			terraform.NewTerraformCmd(),
 			explain.NewExplainCmd(),
+			config.NewConfigCmd(),
 		},
 	}
 
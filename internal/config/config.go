@@ -0,0 +1,209 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	ufcli "github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Source identifies where a resolved configuration value came from.
+// Precedence, highest first: flag > env > config > default.
+type Source string
+
+const (
+	SourceFlag    Source = "flag"
+	SourceEnv     Source = "env"
+	SourceConfig  Source = "config"
+	SourceDefault Source = "default"
+)
+
+// Setting describes a single configurable knob
+type Setting struct {
+	Key     string // Key in ~/.cc/config.yaml
+	Env     string // Environment variable that overrides the config file
+	Default string // Value used when nothing else is set
+	Secret  bool   // Secrets are never printed, only reported as set/unset
+}
+
+// Value is a resolved setting along with where it came from
+type Value struct {
+	Setting
+	Value  string
+	Source Source
+}
+
+// Settings is the list of all known configuration keys
+var Settings = []Setting{
+	{Key: "anthropic_api_key", Env: "ANTHROPIC_API_KEY", Secret: true},
+	{Key: "openai_api_key", Env: "OPENAI_API_KEY", Secret: true},
+	{Key: "openai_model", Env: "CC_OPENAI_MODEL", Default: "gpt-4o"},
+	{Key: "ollama_url", Env: "CC_OLLAMA_URL", Default: "http://localhost:11434"},
+	{Key: "ollama_model", Env: "CC_OLLAMA_MODEL", Default: "llama3.2:latest"},
+	{Key: "explain_length", Env: "CC_EXPLAIN_LENGTH", Default: "long"},
+	{Key: "scan_tool", Env: "CC_SCAN_TOOL", Default: "tfsec"},
+	{Key: "homebrew_install_sha256", Env: "CC_HOMEBREW_INSTALL_SHA256"},
+}
+
+// Package is a Homebrew package listed under `packages:` in the config file
+type Package struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"display_name"`
+	Tap         string `yaml:"tap"` // Tap to add before installing, e.g. mycompany/internal
+}
+
+// Config holds the values read from the config file
+type Config struct {
+	Path     string
+	Packages []Package // Extra packages for `cc setup` to manage
+	values   map[string]string
+}
+
+// DefaultPath returns the location of the user config file
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".cc", "config.yaml"), nil
+}
+
+// The config file is read once per process and shared by every caller
+var (
+	loadOnce sync.Once
+	loaded   *Config
+	loadErr  error
+)
+
+// Load returns the config from ~/.cc/config.yaml, reading the file on the
+// first call only. A missing file is not an error and simply yields an
+// empty config.
+func Load() (*Config, error) {
+	loadOnce.Do(func() {
+		loaded, loadErr = load()
+	})
+	return loaded, loadErr
+}
+
+// load reads and parses the config file
+func load() (*Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{Path: path, values: map[string]string{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	// Settings are plain scalars; the packages list is decoded separately
+	var nodes map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	for key, node := range nodes {
+		if key == "packages" {
+			if err := node.Decode(&cfg.Packages); err != nil {
+				return nil, fmt.Errorf("failed to parse packages in %s: %w", path, err)
+			}
+			continue
+		}
+		if node.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("failed to parse config %s: %s must be a single value", path, key)
+		}
+		cfg.values[key] = node.Value
+	}
+	return cfg, nil
+}
+
+// Get resolves a setting from env, config, and default, in that order
+func (c *Config) Get(key string) Value {
+	setting := lookupSetting(key)
+
+	if setting.Env != "" {
+		if v := os.Getenv(setting.Env); v != "" {
+			return Value{Setting: setting, Value: v, Source: SourceEnv}
+		}
+	}
+	if v, ok := c.values[key]; ok && v != "" {
+		return Value{Setting: setting, Value: v, Source: SourceConfig}
+	}
+	return Value{Setting: setting, Value: setting.Default, Source: SourceDefault}
+}
+
+// Resolve is like Get but gives an explicitly set command-line flag
+// the highest precedence
+func (c *Config) Resolve(key string, cCtx *ufcli.Context, flag string) Value {
+	if cCtx != nil && cCtx.IsSet(flag) {
+		return Value{Setting: lookupSetting(key), Value: cCtx.String(flag), Source: SourceFlag}
+	}
+	return c.Get(key)
+}
+
+// String reads a setting from the loaded config, ignoring load errors.
+// It is a convenience for callers that only need the value.
+func String(key string) string {
+	cfg, err := Load()
+	if err != nil {
+		return lookupSetting(key).Default
+	}
+	return cfg.Get(key).Value
+}
+
+// lookupSetting finds a setting by key, returning a bare setting for
+// unknown keys so callers never have to nil-check
+func lookupSetting(key string) Setting {
+	for _, s := range Settings {
+		if s.Key == key {
+			return s
+		}
+	}
+	return Setting{Key: key}
+}
+
+// NewConfigCmd creates the config command
+func NewConfigCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "config",
+		Usage: "Print the effective configuration and where each value came from",
+		Action: func(c *ufcli.Context) error {
+			cfg, err := Load()
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Config file: %s\n", cfg.Path)
+			fmt.Println("Precedence: flag > env > config > default")
+			fmt.Println()
+
+			fmt.Printf("%-20s %-30s %-10s %-20s\n", "Key", "Value", "Source", "Env")
+			fmt.Println(strings.Repeat("-", 80))
+			for _, setting := range Settings {
+				v := cfg.Get(setting.Key)
+				value := v.Value
+				if setting.Secret {
+					value = "unset"
+					if v.Value != "" {
+						value = "set"
+					}
+				} else if value == "" {
+					value = "-"
+				}
+				fmt.Printf("%-20s %-30s %-10s %-20s\n", setting.Key, value, v.Source, setting.Env)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package explain
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/christopher.carver/cc/internal/shell"
+)
+
+// batchResult records the outcome of explaining a single module
+type batchResult struct {
+	module string
+	output string
+	err    error
+}
+
+// explainBatch explains every module under root using a bounded worker pool,
+// writing one markdown file per module into outputDir
+func explainBatch(ctx context.Context, root, outputDir string, workers int, opts explainOptions) error {
+	modules, err := findModuleDirs(root)
+	if err != nil {
+		return err
+	}
+	if len(modules) == 0 {
+		return fmt.Errorf("no Terraform modules found under %s", root)
+	}
+
+	// Paths like aws/s3 and aws_s3 share a file name; catch that up front so
+	// two workers never write the same file
+	docModules := make(map[string]string)
+	for _, module := range modules {
+		name := moduleDocName(root, module)
+		if other, ok := docModules[name]; ok {
+			return fmt.Errorf("modules %s and %s would both be written to %s; rename one of them", other, module, name)
+		}
+		docModules[name] = module
+	}
+
+	// Workers run concurrently, so only the progress lines below are printed
+	opts.quiet = true
+
+	if !shell.IsDryRun(ctx) {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	fmt.Printf("Found %d module(s) under %s\n\n", len(modules), root)
+
+	jobs := make(chan string)
+	results := make(chan batchResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for module := range jobs {
+				output, err := explainModuleToFile(ctx, root, module, outputDir, opts)
+				results <- batchResult{module: module, output: output, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, module := range modules {
+			select {
+			case jobs <- module:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Print a progress line per module as results come in
+	var failed []batchResult
+	done := 0
+	for result := range results {
+		done++
+		if result.err != nil {
+			failed = append(failed, result)
+			fmt.Printf("[%d/%d] ✗ %s: %v\n", done, len(modules), result.module, result.err)
+			continue
+		}
+		fmt.Printf("[%d/%d] ✓ %s -> %s\n", done, len(modules), result.module, result.output)
+	}
+
+	// Final summary
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Printf("Explained %d of %d module(s) into %s\n", len(modules)-len(failed), len(modules), outputDir)
+	if len(failed) > 0 {
+		fmt.Println("Failed modules:")
+		for _, result := range failed {
+			fmt.Printf("  - %s\n", result.module)
+		}
+		fmt.Println(strings.Repeat("=", 80))
+		return fmt.Errorf("%d module(s) failed", len(failed))
+	}
+	fmt.Println(strings.Repeat("=", 80))
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// explainModuleToFile explains a single module and writes the markdown file,
+// returning the path of the written file
+func explainModuleToFile(ctx context.Context, root, module, outputDir string, opts explainOptions) (string, error) {
+	moduleText, _, err := readModuleFiles(filepath.Join(root, module))
+	if err != nil {
+		return "", err
+	}
+
+	explanation, err := callAI(ctx, buildPrompt(moduleText, opts), opts)
+	if err != nil {
+		return "", err
+	}
+
+	outputPath := filepath.Join(outputDir, moduleDocName(root, module))
+	doc := fmt.Sprintf("# %s\n\n%s\n", module, explanation)
+	if shell.IsDryRun(ctx) {
+		fmt.Printf("Would write %s\n", outputPath)
+		return outputPath, nil
+	}
+	if err := os.WriteFile(outputPath, []byte(doc), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	return outputPath, nil
+}
+
+// findModuleDirs returns every directory under root containing .tf files,
+// relative to root and sorted. Hidden directories and .terraform are skipped.
+func findModuleDirs(root string) ([]string, error) {
+	seen := make(map[string]bool)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".tf") {
+			rel, err := filepath.Rel(root, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+			seen[rel] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+
+	modules := make([]string, 0, len(seen))
+	for module := range seen {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	return modules, nil
+}
+
+// moduleDocName builds the markdown file name for a module from its path
+// relative to root, e.g. "aws/s3" becomes "aws_s3.md"
+func moduleDocName(root, module string) string {
+	if module == "." {
+		return filepath.Base(root) + ".md"
+	}
+	return strings.ReplaceAll(filepath.ToSlash(module), "/", "_") + ".md"
+}
@@ -3,24 +3,84 @@ package explain
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
 )
 
-// callClaude sends a prompt to Claude API and returns the response
-func callClaude(ctx context.Context, prompt string, apiKey string) (string, error) {
+// claudeModel is the Claude model used for explanations
+const claudeModel = anthropic.ModelClaude3_5SonnetLatest
+
+// claudeBaseURL is the Anthropic API endpoint probed before the first call
+const claudeBaseURL = "https://api.anthropic.com"
+
+// claudePreflightTimeout keeps the connectivity check from slowing the happy path
+const claudePreflightTimeout = 800 * time.Millisecond
+
+// claudeReachable is set once a probe succeeds, so retries, follow-up
+// questions, and batch modules don't probe again
+var claudeReachable atomic.Bool
+
+// checkClaudeReachable does a quick HEAD request to the Anthropic API so an
+// unreachable network is reported clearly instead of as a generic SDK error.
+// Any HTTP response, even an error status, means the API is reachable. The
+// cause is wrapped so a timeout is still retried by callBackend.
+func checkClaudeReachable(ctx context.Context) error {
+	if claudeReachable.Load() {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, "HEAD", claudeBaseURL, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: claudePreflightTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't reach Anthropic API at %s - check your network/proxy, or use --local to use Ollama: %w", claudeBaseURL, err)
+	}
+	resp.Body.Close()
+	claudeReachable.Store(true)
+	return nil
+}
+
+// callClaude sends a conversation to Claude API and returns the response.
+// When stream is non-nil the response is also written to it as it arrives.
+func callClaude(ctx context.Context, turns []chatTurn, apiKey string, stream io.Writer) (string, error) {
+	if err := checkClaudeReachable(ctx); err != nil {
+		return "", err
+	}
+
+	// callBackend retries failed calls itself; SDK retries on top would multiply them
 	client := anthropic.NewClient(
 		option.WithAPIKey(apiKey),
+		option.WithMaxRetries(0),
 	)
 
-	message, err := client.Messages.New(ctx, anthropic.MessageNewParams{
-		Model:     anthropic.F(anthropic.ModelClaude3_5SonnetLatest),
+	messages := make([]anthropic.MessageParam, 0, len(turns))
+	for _, turn := range turns {
+		if turn.Role == roleAssistant {
+			messages = append(messages, anthropic.NewAssistantMessage(anthropic.NewTextBlock(turn.Text)))
+		} else {
+			messages = append(messages, anthropic.NewUserMessage(anthropic.NewTextBlock(turn.Text)))
+		}
+	}
+
+	params := anthropic.MessageNewParams{
+		Model:     anthropic.F(claudeModel),
 		MaxTokens: anthropic.F(int64(4096)),
-		Messages: anthropic.F([]anthropic.MessageParam{
-			anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
-		}),
-	})
+		Messages:  anthropic.F(messages),
+	}
+	if stream != nil {
+		return streamClaude(ctx, client, params, stream)
+	}
+
+	message, err := client.Messages.New(ctx, params)
 
 	if err != nil {
 		return "", fmt.Errorf("claude api error: %w", err)
@@ -34,3 +94,28 @@ func callClaude(ctx context.Context, prompt string, apiKey string) (string, erro
 
 	return "", fmt.Errorf("no response from claude")
 }
+
+// streamClaude writes Claude's response to w as text deltas arrive and
+// returns the assembled response
+func streamClaude(ctx context.Context, client *anthropic.Client, params anthropic.MessageNewParams, w io.Writer) (string, error) {
+	stream := client.Messages.NewStreaming(ctx, params)
+	defer stream.Close()
+
+	var text strings.Builder
+	for stream.Next() {
+		event, ok := stream.Current().AsUnion().(anthropic.ContentBlockDeltaEvent)
+		if !ok || event.Delta.Text == "" {
+			continue
+		}
+		fmt.Fprint(w, event.Delta.Text)
+		text.WriteString(event.Delta.Text)
+	}
+	if err := stream.Err(); err != nil {
+		return "", fmt.Errorf("claude api error: %w", err)
+	}
+
+	if text.Len() == 0 {
+		return "", fmt.Errorf("no response from claude")
+	}
+	return text.String(), nil
+}
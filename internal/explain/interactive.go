@@ -0,0 +1,81 @@
+package explain
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Conversation roles, matching the Claude API's message roles
+const (
+	roleUser      = "user"
+	roleAssistant = "assistant"
+)
+
+// chatTurn is one message in a conversation with the AI backend
+type chatTurn struct {
+	Role string
+	Text string
+}
+
+// flattenConversation renders a conversation as a single prompt for backends
+// without multi-turn support. A lone user turn is returned unchanged.
+func flattenConversation(turns []chatTurn) string {
+	if len(turns) == 1 {
+		return turns[0].Text
+	}
+
+	var b strings.Builder
+	for i, turn := range turns {
+		switch {
+		case i == 0:
+			b.WriteString(turn.Text)
+		case turn.Role == roleUser:
+			b.WriteString("User: " + turn.Text)
+		default:
+			b.WriteString("Assistant: " + turn.Text)
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Assistant:")
+	return b.String()
+}
+
+// runFollowUps reads follow-up questions from stdin and answers each with the
+// whole conversation so far, until an empty line, "quit", or end of input
+func runFollowUps(ctx context.Context, turns []chatTurn, opts explainOptions) error {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Println("\nAsk a follow-up question (empty line or 'quit' to exit).")
+
+	for {
+		fmt.Print("\n> ")
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("error reading input: %w", err)
+		}
+		question := strings.TrimSpace(line)
+		if question == "" || question == "quit" {
+			return nil
+		}
+
+		turns = append(turns, chatTurn{Role: roleUser, Text: question})
+		answer, err := callAIConversation(ctx, turns, opts)
+		if err != nil {
+			// Drop the unanswered question so the next one can be asked
+			turns = turns[:len(turns)-1]
+			fmt.Printf("⚠️  Failed to answer: %v\n", err)
+			continue
+		}
+		turns = append(turns, chatTurn{Role: roleAssistant, Text: answer})
+
+		// A streamed answer has already been printed
+		if opts.stream == nil {
+			fmt.Println()
+			fmt.Print(answer)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,53 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// backendEvent is a structured record of one AI backend call, emitted to
+// stderr as a JSON line when --log-json is set
+type backendEvent struct {
+	Time           time.Time `json:"time"`
+	Backend        string    `json:"backend"`
+	Model          string    `json:"model"`
+	Outcome        string    `json:"outcome"`
+	Error          string    `json:"error,omitempty"`
+	LatencyMS      int64     `json:"latency_ms"`
+	PromptTokens   int       `json:"prompt_tokens_estimate"`
+	ResponseTokens int       `json:"response_tokens_estimate"`
+}
+
+// estimateTokens roughly approximates a token count at ~4 characters per token
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// logBackendCall writes a backend event to stderr when JSON logging is enabled
+func logBackendCall(opts explainOptions, backend, model, prompt, response string, start time.Time, err error) {
+	if !opts.logJSON {
+		return
+	}
+
+	event := backendEvent{
+		Time:           start.UTC(),
+		Backend:        backend,
+		Model:          model,
+		Outcome:        "success",
+		LatencyMS:      time.Since(start).Milliseconds(),
+		PromptTokens:   estimateTokens(prompt),
+		ResponseTokens: estimateTokens(response),
+	}
+	if err != nil {
+		event.Outcome = "error"
+		event.Error = err.Error()
+	}
+
+	data, marshalErr := json.Marshal(event)
+	if marshalErr != nil {
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(data))
+}
@@ -7,19 +7,23 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
-)
 
-const (
-	defaultOllamaURL = "http://localhost:11434"
-	defaultModel     = "llama3.2:latest" // Good balance of quality and speed
+	"github.com/christopher.carver/cc/internal/config"
 )
 
 // OllamaRequest represents the request structure for Ollama API
 type OllamaRequest struct {
-	Model  string `json:"model"`
-	Prompt string `json:"prompt"`
-	Stream bool   `json:"stream"`
+	Model   string         `json:"model"`
+	Prompt  string         `json:"prompt"`
+	Stream  bool           `json:"stream"`
+	Options *OllamaOptions `json:"options,omitempty"`
+}
+
+// OllamaOptions holds the model parameters sent with an Ollama request
+type OllamaOptions struct {
+	NumPredict int `json:"num_predict,omitempty"`
 }
 
 // OllamaResponse represents the response structure from Ollama API
@@ -31,17 +35,22 @@ type OllamaResponse struct {
 }
 
 // callOllama sends a prompt to local Ollama and returns the response
-func callOllama(ctx context.Context, prompt string) (string, error) {
+// numPredict caps the number of tokens generated; zero uses the model default.
+// When stream is non-nil the response is also written to it as it arrives.
+func callOllama(ctx context.Context, prompt string, numPredict int, stream io.Writer) (string, error) {
 	// Check if Ollama is running
 	if !isOllamaRunning(ctx) {
 		return "", fmt.Errorf("ollama is not running. Please start it with: ollama serve")
 	}
 
-	// Prepare request
+	// Prepare request; llama3.2 is the default for its balance of quality and speed
 	reqBody := OllamaRequest{
-		Model:  defaultModel,
+		Model:  config.String("ollama_model"),
 		Prompt: prompt,
-		Stream: false,
+		Stream: stream != nil,
+	}
+	if numPredict > 0 {
+		reqBody.Options = &OllamaOptions{NumPredict: numPredict}
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -50,7 +59,7 @@ func callOllama(ctx context.Context, prompt string) (string, error) {
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", defaultOllamaURL+"/api/generate", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", config.String("ollama_url")+"/api/generate", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -66,7 +75,11 @@ func callOllama(ctx context.Context, prompt string) (string, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("ollama returned status %d: %s", resp.StatusCode, string(body))
+		return "", &statusError{Service: "ollama", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if stream != nil {
+		return streamOllama(resp.Body, stream)
 	}
 
 	// Parse response
@@ -82,9 +95,34 @@ func callOllama(ctx context.Context, prompt string) (string, error) {
 	return ollamaResp.Response, nil
 }
 
+// streamOllama reads Ollama's newline-delimited JSON chunks, writing each to
+// w as it arrives, and returns the assembled response
+func streamOllama(body io.Reader, w io.Writer) (string, error) {
+	var text strings.Builder
+	decoder := json.NewDecoder(body)
+	for {
+		var chunk OllamaResponse
+		if err := decoder.Decode(&chunk); err == io.EOF {
+			break
+		} else if err != nil {
+			return "", fmt.Errorf("failed to decode response: %w", err)
+		}
+		fmt.Fprint(w, chunk.Response)
+		text.WriteString(chunk.Response)
+		if chunk.Done {
+			break
+		}
+	}
+
+	if text.Len() == 0 {
+		return "", fmt.Errorf("empty response from ollama")
+	}
+	return text.String(), nil
+}
+
 // isOllamaRunning checks if Ollama is running and accessible
 func isOllamaRunning(ctx context.Context) bool {
-	req, err := http.NewRequestWithContext(ctx, "GET", defaultOllamaURL+"/api/tags", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", config.String("ollama_url")+"/api/tags", nil)
 	if err != nil {
 		return false
 	}
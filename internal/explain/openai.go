@@ -0,0 +1,91 @@
+package explain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/christopher.carver/cc/internal/config"
+)
+
+// openAIURL is the OpenAI chat completions endpoint
+const openAIURL = "https://api.openai.com/v1/chat/completions"
+
+// openAIMessage is one message in an OpenAI chat completion request
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// openAIRequest represents the request structure for the OpenAI API
+type openAIRequest struct {
+	Model     string          `json:"model"`
+	Messages  []openAIMessage `json:"messages"`
+	MaxTokens int             `json:"max_tokens"`
+}
+
+// openAIResponse represents the response structure from the OpenAI API
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// callOpenAI sends a conversation to the OpenAI API and returns the response
+func callOpenAI(ctx context.Context, turns []chatTurn, apiKey string) (string, error) {
+	messages := make([]openAIMessage, 0, len(turns))
+	for _, turn := range turns {
+		messages = append(messages, openAIMessage{Role: turn.Role, Content: turn.Text})
+	}
+
+	jsonData, err := json.Marshal(openAIRequest{
+		Model:     config.String("openai_model"),
+		Messages:  messages,
+		MaxTokens: 4096,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", openAIURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request to openai: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read openai response: %w", err)
+	}
+
+	var openAIResp openAIResponse
+	if err := json.Unmarshal(body, &openAIResp); err != nil {
+		return "", &statusError{Service: "openai", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	if openAIResp.Error != nil {
+		return "", &statusError{Service: "openai", StatusCode: resp.StatusCode, Body: openAIResp.Error.Message}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", &statusError{Service: "openai", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	if len(openAIResp.Choices) == 0 || openAIResp.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("no response from openai")
+	}
+
+	return openAIResp.Choices[0].Message.Content, nil
+}
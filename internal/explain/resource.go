@@ -0,0 +1,99 @@
+package explain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// explainResource extracts a single resource block from a .tf file and
+// asks the AI to explain just that block
+func explainResource(ctx context.Context, file, address string, opts explainOptions) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	block, err := extractResourceBlock(string(data), address)
+	if err != nil {
+		return fmt.Errorf("%w in %s", err, file)
+	}
+
+	fmt.Printf("Explaining %s from %s\n\n", address, file)
+	fmt.Println("Generating explanation...")
+	explanation, err := callAI(ctx, buildResourcePrompt(address, block), opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate explanation: %w", err)
+	}
+
+	// Display results
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Println("EXPLANATION")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println(explanation)
+	fmt.Println(strings.Repeat("=", 80))
+
+	return nil
+}
+
+// extractResourceBlock finds `resource "type" "name" { ... }` for an address
+// of the form type.name (or data.type.name for data sources) and returns the
+// full block text, matching braces outside of quoted strings
+func extractResourceBlock(content, address string) (string, error) {
+	kind := "resource"
+	parts := strings.Split(address, ".")
+	if len(parts) == 3 && parts[0] == "data" {
+		kind = "data"
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return "", fmt.Errorf("address must be <type>.<name> or data.<type>.<name>, got %s", address)
+	}
+
+	header := regexp.MustCompile(fmt.Sprintf(`(?m)^\s*%s\s+"%s"\s+"%s"\s*\{`,
+		kind, regexp.QuoteMeta(parts[0]), regexp.QuoteMeta(parts[1])))
+	loc := header.FindStringIndex(content)
+	if loc == nil {
+		return "", fmt.Errorf("%s not found", address)
+	}
+
+	depth := 0
+	inString := false
+	for i := loc[1] - 1; i < len(content); i++ {
+		switch ch := content[i]; {
+		case inString && ch == '\\':
+			i++ // skip the escaped character
+		case ch == '"':
+			inString = !inString
+		case inString:
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(content[loc[0] : i+1]), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("unterminated block for %s", address)
+}
+
+// buildResourcePrompt creates the AI prompt for a single resource block
+func buildResourcePrompt(address, block string) string {
+	return fmt.Sprintf(`You are a Terraform expert. Explain the following Terraform block (%s).
+
+Your explanation should include:
+1. **Purpose**: What does this resource do?
+2. **Arguments**: What does each configured argument control?
+3. **Implications**: Security, cost, or operational considerations worth knowing.
+
+Be specific but concise.
+
+Block:
+%s
+
+Provide your explanation in markdown format.`, address, block)
+}
@@ -0,0 +1,108 @@
+package explain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+)
+
+// defaultRetries is how many times a failed backend call is retried
+const defaultRetries = 2
+
+// retryBaseDelay is the wait before the first retry; it doubles each attempt
+const retryBaseDelay = 2 * time.Second
+
+// statusError is a non-200 HTTP response from a backend
+type statusError struct {
+	Service    string
+	StatusCode int
+	Body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s returned status %d: %s", e.Service, e.StatusCode, e.Body)
+}
+
+// isRetryable reports whether a backend error is likely transient: rate
+// limits, server errors, timeouts, and dropped connections
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *anthropic.Error
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.StatusCode)
+	}
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return isRetryableStatus(statusErr.StatusCode)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isRetryableStatus reports whether an HTTP status is worth retrying
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusRequestTimeout || code >= 500
+}
+
+// streamTracker records whether anything was written to the stream, since a
+// call that already printed part of a response can't be retried cleanly
+type streamTracker struct {
+	w       io.Writer
+	written bool
+}
+
+func (t *streamTracker) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		t.written = true
+	}
+	return t.w.Write(p)
+}
+
+// callBackend sends a conversation to one backend, retrying transient
+// failures up to opts.retries times with exponential backoff. Waiting stops
+// as soon as the context is cancelled.
+func callBackend(ctx context.Context, backend string, turns []chatTurn, opts explainOptions) (string, error) {
+	delay := retryBaseDelay
+	for attempt := 1; ; attempt++ {
+		attemptOpts := opts
+		var tracker *streamTracker
+		if opts.stream != nil {
+			tracker = &streamTracker{w: opts.stream}
+			attemptOpts.stream = tracker
+		}
+
+		response, err := callBackendOnce(ctx, backend, turns, attemptOpts)
+		// Backends report a deadline as their own failure, e.g. Ollama as
+		// not running, so name the timeout instead
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("timed out: %w", ctx.Err())
+		}
+		// A cancelled context ends the retries too
+		if err == nil || attempt > opts.retries || ctx.Err() != nil || !isRetryable(err) || (tracker != nil && tracker.written) {
+			return response, err
+		}
+
+		opts.status("⚠️  %s API failed: %v\n", backendNames[backend], err)
+		opts.status("Retrying in %s (attempt %d of %d)...\n", delay, attempt+1, opts.retries+1)
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
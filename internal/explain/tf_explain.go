@@ -3,13 +3,70 @@ package explain
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/christopher.carver/cc/internal/config"
+	"github.com/christopher.carver/cc/internal/shell"
 	ufcli "github.com/urfave/cli/v2"
 )
 
+// Explanation lengths supported by the --length flag
+const (
+	lengthShort  = "short"
+	lengthMedium = "medium"
+	lengthLong   = "long"
+)
+
+// lengthTokens maps each explanation length to the Ollama num_predict budget
+var lengthTokens = map[string]int{
+	lengthShort:  256,
+	lengthMedium: 1024,
+	lengthLong:   4096,
+}
+
+// Backends accepted by the --backend flag
+const (
+	backendClaude = "claude"
+	backendOllama = "ollama"
+	backendOpenAI = "openai"
+)
+
+// backendNames are the display names used in status messages
+var backendNames = map[string]string{
+	backendClaude: "Claude",
+	backendOllama: "Ollama",
+	backendOpenAI: "OpenAI",
+}
+
+// explainOptions controls how an explanation is generated
+type explainOptions struct {
+	forceLocal  bool      // Skip Claude and use local Ollama
+	backend     string    // Use only this backend; empty for the automatic fallback order
+	length      string    // short, medium, or long
+	diagram     bool      // Ask for a mermaid diagram of resource relationships
+	logJSON     bool      // Emit structured backend events to stderr
+	strict      bool      // Require real Terraform content before calling the AI
+	interactive bool      // Answer follow-up questions after the explanation
+	preview     bool      // Print the prompt instead of calling a backend
+	stream      io.Writer // Receives the response as it arrives; nil to wait for all of it
+	output      string    // Also write the explanation as markdown to this file
+	appendOut   bool      // Append to output instead of replacing it
+	retries     int       // Retry transient backend failures this many times
+	quiet       bool      // Don't print which backend is used, retries, or fallbacks
+}
+
+// status prints a backend status line unless the options are quiet
+func (o explainOptions) status(format string, args ...any) {
+	if !o.quiet {
+		fmt.Printf(format, args...)
+	}
+}
+
 // NewExplainCmd creates the explain command
 func NewExplainCmd() *ufcli.Command {
 	return &ufcli.Command{
@@ -26,6 +83,63 @@ func NewExplainCmd() *ufcli.Command {
 						Aliases: []string{"l"},
 						Usage:   "Force use of local Ollama (skip Claude API)",
 					},
+					&ufcli.StringFlag{
+						Name:  "backend",
+						Usage: "Use only this backend: claude, ollama, or openai (default: Claude or OpenAI, falling back to Ollama)",
+					},
+					&ufcli.IntFlag{
+						Name:  "retries",
+						Usage: "Retry rate-limited, timed-out, or failed backend calls this many times",
+						Value: defaultRetries,
+					},
+					&ufcli.DurationFlag{
+						Name:  "timeout",
+						Usage: "Give up after this long in total, including retries (e.g. 2m; 0 means no limit)",
+					},
+					&ufcli.StringFlag{
+						Name:  "length",
+						Usage: "Explanation length: short, medium, or long",
+						Value: lengthLong,
+					},
+					&ufcli.BoolFlag{
+						Name:  "diagram",
+						Usage: "Include a mermaid diagram of the module's resources and dependencies",
+					},
+					&ufcli.BoolFlag{
+						Name:  "log-json",
+						Usage: "Emit structured JSON events about backend calls to stderr",
+					},
+					&ufcli.BoolFlag{
+						Name:  "strict",
+						Usage: "Fail unless the module has at least one .tf file with non-comment content",
+					},
+					&ufcli.BoolFlag{
+						Name:    "interactive",
+						Aliases: []string{"i"},
+						Usage:   "Keep asking follow-up questions about the module after the explanation",
+					},
+					&ufcli.BoolFlag{
+						Name:  "prompt-preview",
+						Usage: "Print the prompt that would be sent and exit without calling a backend",
+					},
+					&ufcli.BoolFlag{
+						Name:  "stream",
+						Usage: "Print the explanation as it is generated instead of all at once",
+					},
+					&ufcli.BoolFlag{
+						Name:    "recursive",
+						Aliases: []string{"r"},
+						Usage:   "Explain every module (directory with .tf files) under the path, one section each",
+					},
+					&ufcli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Also write the explanation as markdown to this file, creating parent directories",
+					},
+					&ufcli.BoolFlag{
+						Name:  "append",
+						Usage: "Append to the --output file instead of replacing it",
+					},
 				},
 				Action: func(c *ufcli.Context) error {
 					path := c.Args().First()
@@ -38,14 +152,321 @@ func NewExplainCmd() *ufcli.Command {
 						return err
 					}
 
-					forceLocal := c.Bool("local")
-					return explainTerraform(c.Context, safePath, forceLocal)
+					length, err := resolveLength(c)
+					if err != nil {
+						return err
+					}
+
+					backend, err := resolveBackend(c)
+					if err != nil {
+						return err
+					}
+
+					retries := c.Int("retries")
+					if retries < 0 {
+						return fmt.Errorf("retries must be at least 0, got %d", retries)
+					}
+
+					opts := explainOptions{
+						forceLocal:  c.Bool("local"),
+						backend:     backend,
+						length:      length,
+						diagram:     c.Bool("diagram"),
+						logJSON:     c.Bool("log-json"),
+						strict:      c.Bool("strict"),
+						interactive: c.Bool("interactive"),
+						preview:     c.Bool("prompt-preview"),
+						retries:     retries,
+					}
+					if c.Bool("stream") {
+						opts.stream = os.Stdout
+					}
+					if opts.output = c.String("output"); opts.output != "" {
+						if err := checkOutputPath(opts.output); err != nil {
+							return err
+						}
+					}
+					opts.appendOut = c.Bool("append")
+					if opts.appendOut && opts.output == "" {
+						return fmt.Errorf("--append requires --output")
+					}
+
+					ctx, cancel, err := explainContext(c)
+					if err != nil {
+						return err
+					}
+					defer cancel()
+
+					if c.Bool("recursive") {
+						if opts.interactive {
+							return fmt.Errorf("--recursive and --interactive cannot be used together")
+						}
+						return explainRecursive(ctx, safePath, opts)
+					}
+					return explainTerraform(ctx, safePath, opts)
+				},
+			},
+			{
+				Name:      "resource",
+				Usage:     "Explain a single resource block from a Terraform file",
+				ArgsUsage: "<file> <address>",
+				Flags: []ufcli.Flag{
+					&ufcli.BoolFlag{
+						Name:    "local",
+						Aliases: []string{"l"},
+						Usage:   "Force use of local Ollama (skip Claude API)",
+					},
+					&ufcli.StringFlag{
+						Name:  "backend",
+						Usage: "Use only this backend: claude, ollama, or openai (default: Claude or OpenAI, falling back to Ollama)",
+					},
+					&ufcli.IntFlag{
+						Name:  "retries",
+						Usage: "Retry rate-limited, timed-out, or failed backend calls this many times",
+						Value: defaultRetries,
+					},
+					&ufcli.DurationFlag{
+						Name:  "timeout",
+						Usage: "Give up after this long in total, including retries (e.g. 2m; 0 means no limit)",
+					},
+				},
+				Action: func(c *ufcli.Context) error {
+					if c.NArg() < 2 {
+						return fmt.Errorf("file and resource address are required")
+					}
+
+					backend, err := resolveBackend(c)
+					if err != nil {
+						return err
+					}
+
+					retries := c.Int("retries")
+					if retries < 0 {
+						return fmt.Errorf("retries must be at least 0, got %d", retries)
+					}
+
+					opts := explainOptions{
+						forceLocal: c.Bool("local"),
+						backend:    backend,
+						length:     lengthLong,
+						retries:    retries,
+					}
+					ctx, cancel, err := explainContext(c)
+					if err != nil {
+						return err
+					}
+					defer cancel()
+					return explainResource(ctx, c.Args().Get(0), c.Args().Get(1), opts)
+				},
+			},
+			{
+				Name:      "batch",
+				Usage:     "Explain every Terraform module under a directory and write markdown docs",
+				ArgsUsage: "<root>",
+				Flags: []ufcli.Flag{
+					&ufcli.StringFlag{
+						Name:     "output-dir",
+						Aliases:  []string{"o"},
+						Usage:    "Directory to write one markdown file per module into",
+						Required: true,
+					},
+					&ufcli.IntFlag{
+						Name:  "workers",
+						Usage: "Number of modules to explain concurrently",
+						Value: 4,
+					},
+					&ufcli.BoolFlag{
+						Name:    "local",
+						Aliases: []string{"l"},
+						Usage:   "Force use of local Ollama (skip Claude API)",
+					},
+					&ufcli.StringFlag{
+						Name:  "backend",
+						Usage: "Use only this backend: claude, ollama, or openai (default: Claude or OpenAI, falling back to Ollama)",
+					},
+					&ufcli.IntFlag{
+						Name:  "retries",
+						Usage: "Retry rate-limited, timed-out, or failed backend calls this many times",
+						Value: defaultRetries,
+					},
+					&ufcli.DurationFlag{
+						Name:  "timeout",
+						Usage: "Give up after this long in total, including retries (e.g. 2m; 0 means no limit)",
+					},
+					&ufcli.StringFlag{
+						Name:  "length",
+						Usage: "Explanation length: short, medium, or long",
+						Value: lengthLong,
+					},
+					&ufcli.BoolFlag{
+						Name:  "log-json",
+						Usage: "Emit structured JSON events about backend calls to stderr",
+					},
+				},
+				Action: func(c *ufcli.Context) error {
+					if c.NArg() < 1 {
+						return fmt.Errorf("root directory is required")
+					}
+
+					safePath, err := validatePath(c.Args().First())
+					if err != nil {
+						return err
+					}
+
+					length, err := resolveLength(c)
+					if err != nil {
+						return err
+					}
+
+					workers := c.Int("workers")
+					if workers < 1 {
+						return fmt.Errorf("workers must be at least 1, got %d", workers)
+					}
+
+					backend, err := resolveBackend(c)
+					if err != nil {
+						return err
+					}
+
+					retries := c.Int("retries")
+					if retries < 0 {
+						return fmt.Errorf("retries must be at least 0, got %d", retries)
+					}
+
+					opts := explainOptions{
+						forceLocal: c.Bool("local"),
+						backend:    backend,
+						length:     length,
+						logJSON:    c.Bool("log-json"),
+						retries:    retries,
+					}
+					ctx, cancel, err := explainContext(c)
+					if err != nil {
+						return err
+					}
+					defer cancel()
+					return explainBatch(ctx, safePath, c.String("output-dir"), workers, opts)
 				},
 			},
 		},
 	}
 }
 
+// explainContext returns the command's context, bounded by --timeout when
+// it is set so retries and fallbacks stop once the time is up
+func explainContext(c *ufcli.Context) (context.Context, context.CancelFunc, error) {
+	timeout := c.Duration("timeout")
+	if timeout < 0 {
+		return nil, nil, fmt.Errorf("timeout must not be negative, got %s", timeout)
+	}
+	if timeout == 0 {
+		ctx, cancel := context.WithCancel(c.Context)
+		return ctx, cancel, nil
+	}
+	ctx, cancel := context.WithTimeout(c.Context, timeout)
+	return ctx, cancel, nil
+}
+
+// resolveLength picks the explanation length from the flag, environment,
+// or config file and validates it
+func resolveLength(c *ufcli.Context) (string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+
+	length := cfg.Resolve("explain_length", c, "length").Value
+	if _, ok := lengthTokens[length]; !ok {
+		return "", fmt.Errorf("length must be one of 'short', 'medium', or 'long', got %s", length)
+	}
+	return length, nil
+}
+
+// checkOutputPath fails early, before any backend is called, if the
+// --output path is an existing directory
+func checkOutputPath(path string) error {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return fmt.Errorf("output path %s is a directory; pass a file name", path)
+	}
+	return nil
+}
+
+// moduleTitle names a module by its path relative to the current
+// directory, falling back to the absolute path
+func moduleTitle(path string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
+
+// writeExplanation writes the explanation as a markdown section titled
+// with the module name, replacing the file or appending to it
+func writeExplanation(path, title, explanation string, appendMode bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendMode {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	doc := fmt.Sprintf("# %s\n\n%s\n", title, explanation)
+	// Separate appended sections from what is already in the file
+	if info, err := f.Stat(); err == nil && appendMode && info.Size() > 0 {
+		doc = "\n" + doc
+	}
+	if _, err := f.WriteString(doc); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// headerWriter writes a header before the first chunk written through it
+type headerWriter struct {
+	w       io.Writer
+	header  string
+	started bool
+}
+
+// Write writes the header on first use, then p
+func (h *headerWriter) Write(p []byte) (int, error) {
+	if !h.started {
+		h.started = true
+		if _, err := io.WriteString(h.w, h.header); err != nil {
+			return 0, err
+		}
+	}
+	return h.w.Write(p)
+}
+
+// resolveBackend validates the --backend flag, which may be empty for the
+// automatic fallback order
+func resolveBackend(c *ufcli.Context) (string, error) {
+	backend := c.String("backend")
+	if backend == "" {
+		return "", nil
+	}
+	if _, ok := backendNames[backend]; !ok {
+		return "", fmt.Errorf("backend must be one of 'claude', 'ollama', or 'openai', got %s", backend)
+	}
+	if c.Bool("local") && backend != backendOllama {
+		return "", fmt.Errorf("--local and --backend %s cannot be used together", backend)
+	}
+	return backend, nil
+}
+
 // validatePath ensures the path exists and is safe to read
 func validatePath(path string) (string, error) {
 	// Convert to absolute path
@@ -69,51 +490,264 @@ func validatePath(path string) (string, error) {
 }
 
 // explainTerraform reads Terraform files and generates an explanation
-func explainTerraform(ctx context.Context, path string, forceLocal bool) error {
+func explainTerraform(ctx context.Context, path string, opts explainOptions) error {
 	fmt.Printf("Analyzing Terraform module at: %s\n\n", path)
 
-	// Read common Terraform files
-	files := []string{"main.tf", "variables.tf", "outputs.tf", "versions.tf", "README.md"}
-	var content []string
-	var foundFiles []string
+	moduleText, foundFiles, err := readModuleFiles(path)
+	if err != nil {
+		return err
+	}
 
-	for _, file := range files {
-		fullPath := filepath.Join(path, file)
-		data, err := os.ReadFile(fullPath)
-		if err == nil {
-			content = append(content, fmt.Sprintf("=== %s ===\n%s", file, string(data)))
-			foundFiles = append(foundFiles, file)
+	fmt.Printf("Found files: %s\n\n", strings.Join(foundFiles, ", "))
+
+	if opts.strict {
+		if err := checkStrict(path, foundFiles); err != nil {
+			return err
 		}
 	}
 
-	if len(content) == 0 {
-		return fmt.Errorf("no Terraform files found in %s", path)
-	}
+	// Build the prompt
+	prompt := buildPrompt(moduleText, opts)
 
-	fmt.Printf("Found files: %s\n\n", strings.Join(foundFiles, ", "))
+	if opts.preview {
+		fmt.Println(prompt)
+		fmt.Fprintf(os.Stderr, "\nPrompt preview: %d characters, ~%d tokens. No backend was called.\n", len(prompt), estimateTokens(prompt))
+		return nil
+	}
 
-	// Build the prompt
-	prompt := buildPrompt(strings.Join(content, "\n\n"))
+	header := "\n" + strings.Repeat("=", 80) + "\nEXPLANATION\n" + strings.Repeat("=", 80) + "\n"
+	if opts.stream != nil {
+		// Status messages print before the first chunk, so hold the header until then
+		opts.stream = &headerWriter{w: opts.stream, header: header}
+	}
 
 	// Get explanation from AI
 	fmt.Println("Generating explanation...")
-	explanation, err := callAI(ctx, prompt, forceLocal)
+	explanation, err := callAI(ctx, prompt, opts)
 	if err != nil {
 		return fmt.Errorf("failed to generate explanation: %w", err)
 	}
 
-	// Display results
-	fmt.Println("\n" + strings.Repeat("=", 80))
-	fmt.Println("EXPLANATION")
-	fmt.Println(strings.Repeat("=", 80))
-	fmt.Println(explanation)
+	// Display results, unless they were already streamed
+	if opts.stream != nil {
+		fmt.Println()
+	} else {
+		fmt.Print(header)
+		fmt.Println(explanation)
+	}
 	fmt.Println(strings.Repeat("=", 80))
 
+	if opts.output != "" && shell.IsDryRun(ctx) {
+		fmt.Printf("Would write the explanation to %s\n", opts.output)
+	} else if opts.output != "" {
+		if err := writeExplanation(opts.output, moduleTitle(path), explanation, opts.appendOut); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Explanation written to %s\n", opts.output)
+	}
+
+	if opts.interactive {
+		turns := []chatTurn{
+			{Role: roleUser, Text: prompt},
+			{Role: roleAssistant, Text: explanation},
+		}
+		return runFollowUps(ctx, turns, opts)
+	}
+
+	return nil
+}
+
+// explainRecursive explains each module under root in turn, with a header
+// naming the module before its explanation. With --output, every module's
+// section goes into the one file.
+func explainRecursive(ctx context.Context, root string, opts explainOptions) error {
+	modules, err := findModuleDirs(root)
+	if err != nil {
+		return err
+	}
+	if len(modules) == 0 {
+		return fmt.Errorf("no Terraform modules found under %s", root)
+	}
+	fmt.Printf("Found %d module(s) under %s\n\n", len(modules), root)
+
+	// Empty the output file once up front and append every module to it,
+	// so a module that fails can't leave a previous run's content behind
+	if opts.output != "" && !opts.appendOut && !opts.preview && !shell.IsDryRun(ctx) {
+		if err := os.MkdirAll(filepath.Dir(opts.output), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", opts.output, err)
+		}
+		if err := os.WriteFile(opts.output, nil, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", opts.output, err)
+		}
+	}
+	opts.appendOut = true
+
+	var failed []string
+	for _, module := range modules {
+		fmt.Println(strings.Repeat("#", 80))
+		fmt.Printf("# MODULE: %s\n", module)
+		fmt.Println(strings.Repeat("#", 80))
+
+		if err := explainTerraform(ctx, filepath.Join(root, module), opts); err != nil {
+			fmt.Printf("✗ %s: %v\n", module, err)
+			failed = append(failed, module)
+		}
+		fmt.Println()
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d module(s) failed: %s", len(failed), len(modules), strings.Join(failed, ", "))
+	}
 	return nil
 }
 
-// buildPrompt creates the AI prompt from Terraform files
-func buildPrompt(moduleText string) string {
+// maxModuleBytes caps the module content sent to the AI (~50k tokens) so
+// large modules stay within the model's context window
+const maxModuleBytes = 200 * 1024
+
+// priorityFiles are read first so they survive truncation of large modules
+var priorityFiles = []string{"main.tf", "variables.tf", "outputs.tf", "versions.tf"}
+
+// readModuleFiles reads every .tf file in a module directory, plus README.md,
+// and returns their combined content along with the names of the files found.
+// Content beyond maxModuleBytes is truncated with a warning.
+func readModuleFiles(path string) (string, []string, error) {
+	files, err := moduleFileNames(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var content []string
+	var foundFiles, skipped []string
+	remaining := maxModuleBytes
+
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(path, file))
+		if err != nil {
+			continue
+		}
+		if remaining <= 0 {
+			skipped = append(skipped, file)
+			continue
+		}
+		if len(data) > remaining {
+			fmt.Printf("⚠️  %s truncated to %d of %d bytes to fit the %d KB limit\n", file, remaining, len(data), maxModuleBytes/1024)
+			data = append(data[:remaining:remaining], []byte("\n... (truncated)")...)
+		}
+		remaining -= len(data)
+		content = append(content, fmt.Sprintf("=== %s ===\n%s", file, string(data)))
+		foundFiles = append(foundFiles, file)
+	}
+
+	if len(content) == 0 {
+		return "", nil, fmt.Errorf("no Terraform files found in %s", path)
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("⚠️  Skipped %d file(s) over the %d KB limit: %s\n", len(skipped), maxModuleBytes/1024, strings.Join(skipped, ", "))
+	}
+
+	return strings.Join(content, "\n\n"), foundFiles, nil
+}
+
+// moduleFileNames lists the module's .tf files, common ones first and the rest
+// alphabetically, followed by README.md if present
+func moduleFileNames(path string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(path, "*.tf"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list .tf files in %s: %w", path, err)
+	}
+
+	present := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		present[filepath.Base(match)] = true
+	}
+
+	var files []string
+	for _, file := range priorityFiles {
+		if present[file] {
+			files = append(files, file)
+			delete(present, file)
+		}
+	}
+	for _, match := range matches {
+		if present[filepath.Base(match)] {
+			files = append(files, filepath.Base(match))
+		}
+	}
+	return append(files, "README.md"), nil
+}
+
+// commentPattern matches Terraform line and block comments
+var commentPattern = regexp.MustCompile(`(?s)/\*.*?\*/|(?m)(#|//).*$`)
+
+// checkStrict ensures at least one .tf file was found and that the .tf files
+// contain something besides comments, so scaffolded templates and
+// README-only directories are not sent to the AI
+func checkStrict(path string, foundFiles []string) error {
+	var tfContent strings.Builder
+	for _, file := range foundFiles {
+		if filepath.Ext(file) != ".tf" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(path, file))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		tfContent.Write(data)
+		tfContent.WriteString("\n")
+	}
+
+	if tfContent.Len() == 0 {
+		return fmt.Errorf("strict mode: no .tf files found in %s", path)
+	}
+	if strings.TrimSpace(commentPattern.ReplaceAllString(tfContent.String(), "")) == "" {
+		return fmt.Errorf("strict mode: .tf files in %s contain only comments or whitespace", path)
+	}
+	return nil
+}
+
+// diagramInstructions is appended to the prompt when a diagram is requested
+const diagramInstructions = `
+
+After the explanation, add a "Diagram" section containing a single fenced ` + "```mermaid" + ` block.
+Use a "graph TD" flowchart with one node per resource (labelled "type.name") and
+an edge for each dependency or reference between resources.`
+
+// buildPrompt creates the AI prompt from Terraform files, sized by length
+func buildPrompt(moduleText string, opts explainOptions) string {
+	prompt := buildBasePrompt(moduleText, opts.length)
+	if opts.diagram {
+		prompt += diagramInstructions
+	}
+	return prompt
+}
+
+// buildBasePrompt creates the explanation prompt for the requested length
+func buildBasePrompt(moduleText string, length string) string {
+	switch length {
+	case lengthShort:
+		return fmt.Sprintf(`You are a Terraform expert. Summarize the following Terraform module files in 2-3 sentences.
+
+Say what the module does, the main resources it manages, and when you would use it. Do not use headings or lists.
+
+Module Files:
+%s`, moduleText)
+	case lengthMedium:
+		return fmt.Sprintf(`You are a Terraform expert. Analyze the following Terraform module files and provide a brief explanation.
+
+Cover, in a few sentences each:
+1. **Purpose**: What does this module do?
+2. **Resources**: What resources does it create or manage?
+3. **Key Variables**: Which inputs matter most?
+
+Keep the whole explanation under 200 words.
+
+Module Files:
+%s
+
+Provide your explanation in markdown format.`, moduleText)
+	}
+
 	return fmt.Sprintf(`You are a Terraform expert. Analyze the following Terraform module files and provide a clear, concise explanation.
 
 Your explanation should include:
@@ -133,21 +767,76 @@ Provide your explanation in markdown format.`, moduleText)
 }
 
 // callAI sends the prompt to an AI service (Claude or Ollama)
-func callAI(ctx context.Context, prompt string, forceLocal bool) (string, error) {
-	// Try Claude API first (unless forced to use local)
-	if !forceLocal {
-		if apiKey := os.Getenv("ANTHROPIC_API_KEY"); apiKey != "" {
-			fmt.Println("Using Claude API...")
-			response, err := callClaude(ctx, prompt, apiKey)
+func callAI(ctx context.Context, prompt string, opts explainOptions) (string, error) {
+	return callAIConversation(ctx, []chatTurn{{Role: roleUser, Text: prompt}}, opts)
+}
+
+// callAIConversation sends a conversation to an AI service. With an
+// explicit --backend only that backend is used. Otherwise Claude is tried,
+// or OpenAI when only its key is set, falling back to local Ollama.
+func callAIConversation(ctx context.Context, turns []chatTurn, opts explainOptions) (string, error) {
+	if opts.backend != "" {
+		return callBackend(ctx, opts.backend, turns, opts)
+	}
+
+	// Try a hosted API first (unless forced to use local)
+	if !opts.forceLocal {
+		remote := ""
+		switch {
+		case config.String("anthropic_api_key") != "":
+			remote = backendClaude
+		case config.String("openai_api_key") != "":
+			remote = backendOpenAI
+		}
+		if remote != "" {
+			response, err := callBackend(ctx, remote, turns, opts)
 			if err == nil {
 				return response, nil
 			}
-			fmt.Printf("⚠️  Claude API failed: %v\n", err)
-			fmt.Println("Falling back to local Ollama...")
+			opts.status("⚠️  %s API failed: %v\n", backendNames[remote], err)
+			opts.status("Falling back to local Ollama...\n")
 		}
 	}
 
 	// Fallback to Ollama
-	fmt.Println("Using local Ollama...")
-	return callOllama(ctx, prompt)
+	return callBackend(ctx, backendOllama, turns, opts)
+}
+
+// callBackendOnce makes a single call to one backend. Claude and OpenAI
+// receive the turns as separate messages; Ollama receives them as a single prompt.
+func callBackendOnce(ctx context.Context, backend string, turns []chatTurn, opts explainOptions) (string, error) {
+	prompt := flattenConversation(turns)
+	start := time.Now()
+
+	var response, model string
+	var err error
+	switch backend {
+	case backendClaude:
+		apiKey := config.String("anthropic_api_key")
+		if apiKey == "" {
+			return "", fmt.Errorf("ANTHROPIC_API_KEY is not set")
+		}
+		opts.status("Using Claude API...\n")
+		model = claudeModel
+		response, err = callClaude(ctx, turns, apiKey, opts.stream)
+	case backendOpenAI:
+		apiKey := config.String("openai_api_key")
+		if apiKey == "" {
+			return "", fmt.Errorf("OPENAI_API_KEY is not set")
+		}
+		opts.status("Using OpenAI API...\n")
+		model = config.String("openai_model")
+		response, err = callOpenAI(ctx, turns, apiKey)
+		// OpenAI responses are not streamed; write them whole
+		if err == nil && opts.stream != nil {
+			fmt.Fprint(opts.stream, response)
+		}
+	default:
+		opts.status("Using local Ollama...\n")
+		model = config.String("ollama_model")
+		response, err = callOllama(ctx, prompt, lengthTokens[opts.length], opts.stream)
+	}
+
+	logBackendCall(opts, backend, model, prompt, response, start, err)
+	return response, err
 }
@@ -0,0 +1,134 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// blameHeaderPattern matches the first line of each porcelain blame entry:
+// the commit, its original line number, and the line number in the file.
+// Abbreviated hashes (7 or more characters) are accepted as well as full ones.
+var blameHeaderPattern = regexp.MustCompile(`^([0-9a-f]{7,40}) \d+ (\d+)`)
+
+// blamedCommit is a commit that last touched some of the blamed lines
+type blamedCommit struct {
+	SHA   string
+	Lines []int
+}
+
+// NewGitBlamePRCmd finds the pull requests that last touched a range of lines
+func NewGitBlamePRCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "blame-pr",
+		Usage:     "Show the pull requests that last changed a range of lines in a file",
+		ArgsUsage: "<file>",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:     "lines",
+				Aliases:  []string{"L"},
+				Usage:    "Line range to blame, as accepted by git blame -L, e.g. 10,20",
+				Required: true,
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 1 {
+				return fmt.Errorf("file is required")
+			}
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+			if !shell.CommandExists("gh") {
+				return fmt.Errorf("gh is not installed. Install it with: brew install gh")
+			}
+
+			commits, err := blameCommits(ctx, c.Args().First(), c.String("lines"))
+			if err != nil {
+				return err
+			}
+
+			for i, commit := range commits {
+				if i > 0 {
+					fmt.Println()
+				}
+				pr, err := findPRForCommit(ctx, commit.SHA)
+				if err != nil {
+					return err
+				}
+				if pr == nil {
+					subject, _ := shell.Run(ctx, "git", "log", "-1", "--format=%s", commit.SHA)
+					fmt.Printf("Commit: %s %s\n", shortSHA(commit.SHA), subject)
+					fmt.Println("PR:     (no pull request found)")
+				} else {
+					printPR(commit.SHA, pr)
+				}
+				fmt.Printf("Lines:  %s\n", formatLineRanges(commit.Lines))
+			}
+			return nil
+		},
+	}
+}
+
+// blameCommits returns the distinct commits that last changed the given
+// lines of file, in the order their lines first appear. Uncommitted lines
+// are reported and left out.
+func blameCommits(ctx context.Context, file, lines string) ([]blamedCommit, error) {
+	output, err := shell.Run(ctx, "git", "blame", "--porcelain", "-L", lines, "--", file)
+	if err != nil {
+		return nil, fmt.Errorf("git blame failed: %w\n%s", err, output)
+	}
+
+	var commits []blamedCommit
+	index := map[string]int{}
+	uncommitted := 0
+	for _, line := range strings.Split(output, "\n") {
+		match := blameHeaderPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		sha := match[1]
+		lineNumber, _ := strconv.Atoi(match[2])
+		if strings.Trim(sha, "0") == "" {
+			uncommitted++
+			continue
+		}
+		i, ok := index[sha]
+		if !ok {
+			i = len(commits)
+			index[sha] = i
+			commits = append(commits, blamedCommit{SHA: sha})
+		}
+		commits[i].Lines = append(commits[i].Lines, lineNumber)
+	}
+
+	if uncommitted > 0 {
+		fmt.Printf("%d line(s) in the range are not committed yet\n\n", uncommitted)
+	}
+	return commits, nil
+}
+
+// formatLineRanges collapses sorted line numbers into ranges, e.g. "3-5, 9"
+func formatLineRanges(lines []int) string {
+	var ranges []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(lines[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", lines[i], lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ", ")
+}
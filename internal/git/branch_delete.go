@@ -0,0 +1,59 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+)
+
+// deleteBranch deletes a local branch, and its origin counterpart when
+// remote is set. The current and default branches are refused, as are
+// protected ones. Unmerged branches need force, like git branch -D.
+func deleteBranch(ctx context.Context, branch string, remote, force bool) error {
+	currentBranch, err := getCurrentBranch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+	if branch == currentBranch {
+		return fmt.Errorf("cannot delete '%s' while it is checked out; switch to another branch first", branch)
+	}
+
+	defaultBranch, err := getDefaultBranch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to determine default branch: %w", err)
+	}
+	if branch == defaultBranch {
+		return fmt.Errorf("refusing to delete the default branch '%s'", branch)
+	}
+	if protected, err := isProtectedBranch(ctx, branch); err == nil && protected {
+		return fmt.Errorf("refusing to delete protected branch '%s' (see 'cc git protect --remove')", branch)
+	}
+
+	if _, err := shell.Run(ctx, "git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		flag := "-d"
+		if force {
+			flag = "-D"
+		}
+		if output, err := shell.Run(ctx, "git", "branch", flag, branch); err != nil {
+			if strings.Contains(output, "not fully merged") {
+				return fmt.Errorf("'%s' is not fully merged; use --force to delete it anyway", branch)
+			}
+			return fmt.Errorf("failed to delete branch: %w\n%s", err, output)
+		}
+		fmt.Printf("✓ Deleted local branch '%s'\n", branch)
+	} else if !remote {
+		return fmt.Errorf("no local branch named '%s'", branch)
+	} else {
+		fmt.Printf("No local branch '%s', deleting only the remote one\n", branch)
+	}
+
+	if remote {
+		if output, err := shell.Run(ctx, "git", "push", "origin", "--delete", branch); err != nil {
+			return fmt.Errorf("failed to delete origin/%s: %w\n%s", branch, err, output)
+		}
+		fmt.Printf("✓ Deleted remote branch 'origin/%s'\n", branch)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/prompt"
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// staleBranch is a local branch whose work has landed on the default branch
+type staleBranch struct {
+	Name   string
+	Squash bool // Squash-merged, so git branch -d would refuse it
+}
+
+// NewGitCleanupCmd deletes local branches already merged into the default branch
+func NewGitCleanupCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "cleanup",
+		Usage: "Delete local branches that are merged into the default branch",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Only list the branches that would be deleted",
+			},
+			&ufcli.BoolFlag{
+				Name:  "squash-merged",
+				Usage: "Also delete branches whose changes were squash-merged",
+			},
+			&ufcli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Delete without asking for confirmation",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			currentBranch, err := getCurrentBranch(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get current branch: %w", err)
+			}
+			defaultBranch, err := getDefaultBranch(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to determine default branch: %w", err)
+			}
+
+			branches, err := findStaleBranches(ctx, defaultBranch, currentBranch, c.Bool("squash-merged"))
+			if err != nil {
+				return err
+			}
+			if len(branches) == 0 {
+				fmt.Printf("No local branches merged into '%s'\n", defaultBranch)
+				return nil
+			}
+
+			fmt.Printf("Branches merged into '%s':\n", defaultBranch)
+			for _, b := range branches {
+				if b.Squash {
+					fmt.Printf("  %s (squash-merged)\n", b.Name)
+				} else {
+					fmt.Printf("  %s\n", b.Name)
+				}
+			}
+
+			if c.Bool("dry-run") || shell.IsDryRun(ctx) {
+				return nil
+			}
+
+			if !c.Bool("yes") {
+				confirmed, err := prompt.Confirm(fmt.Sprintf("Delete %d branch(es)?", len(branches)))
+				if errors.Is(err, prompt.ErrNotInteractive) {
+					return fmt.Errorf("%w; pass --yes to delete without confirmation", err)
+				}
+				if err != nil {
+					return fmt.Errorf("error reading input: %w", err)
+				}
+				if !confirmed {
+					fmt.Println("Cleanup cancelled")
+					return nil
+				}
+			}
+
+			var failed []string
+			for _, b := range branches {
+				if err := deleteBranch(ctx, b.Name, false, b.Squash); err != nil {
+					fmt.Printf("✗ %s: %v\n", b.Name, err)
+					failed = append(failed, b.Name)
+				}
+			}
+			if len(failed) > 0 {
+				return fmt.Errorf("failed to delete %d branch(es): %s", len(failed), strings.Join(failed, ", "))
+			}
+			return nil
+		},
+	}
+}
+
+// findStaleBranches lists local branches merged into base, excluding base,
+// the current branch, and protected branches. With squash it also finds
+// branches whose combined diff already exists on base.
+func findStaleBranches(ctx context.Context, base, current string, squash bool) ([]staleBranch, error) {
+	merged, err := listBranches(ctx, "--merged", base)
+	if err != nil {
+		return nil, err
+	}
+
+	protected, err := getProtectedBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skip := map[string]bool{base: true, current: true}
+	for _, b := range protected {
+		skip[b] = true
+	}
+
+	var stale []staleBranch
+	for _, b := range merged {
+		if !skip[b] {
+			stale = append(stale, staleBranch{Name: b})
+		}
+	}
+	if !squash {
+		return stale, nil
+	}
+
+	unmerged, err := listBranches(ctx, "--no-merged", base)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range unmerged {
+		if skip[b] {
+			continue
+		}
+		if ok, err := isSquashMerged(ctx, base, b); err == nil && ok {
+			stale = append(stale, staleBranch{Name: b, Squash: true})
+		}
+	}
+	return stale, nil
+}
+
+// listBranches returns local branch names from git branch with a filter
+// such as --merged or --no-merged
+func listBranches(ctx context.Context, filter, base string) ([]string, error) {
+	output, err := shell.Run(ctx, "git", "branch", filter, base, "--format=%(refname:short)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// isSquashMerged reports whether branch's changes since it forked from base
+// are already on base. The branch is collapsed into one temporary commit on
+// its merge base, and git cherry checks whether base has an equivalent patch.
+func isSquashMerged(ctx context.Context, base, branch string) (bool, error) {
+	mergeBase, err := shell.Run(ctx, "git", "merge-base", base, branch)
+	if err != nil {
+		return false, err
+	}
+	squashed, err := shell.Run(ctx, "git", "commit-tree", branch+"^{tree}", "-p", mergeBase, "-m", "cc cleanup squash check")
+	if err != nil {
+		return false, err
+	}
+	output, err := shell.Run(ctx, "git", "cherry", base, squashed)
+	if err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(output, "-"), nil
+}
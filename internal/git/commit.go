@@ -0,0 +1,111 @@
+package git
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// conventionalTypes are the commit types accepted by --type
+var conventionalTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// NewGitCommitCmd commits staged changes, optionally with a conventional-commit prefix
+func NewGitCommitCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "commit",
+		Usage: "Commit staged changes, optionally prefixed as a conventional commit, e.g. feat(api): msg",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:    "message",
+				Aliases: []string{"m"},
+				Usage:   "Commit message",
+			},
+			&ufcli.BoolFlag{
+				Name:    "all",
+				Aliases: []string{"a"},
+				Usage:   "Stage every change (git add -A) before committing",
+			},
+			&ufcli.StringFlag{
+				Name:    "type",
+				Aliases: []string{"t"},
+				Usage:   "Conventional commit type: " + strings.Join(conventionalTypes, ", "),
+			},
+			&ufcli.StringFlag{
+				Name:    "scope",
+				Aliases: []string{"s"},
+				Usage:   "Conventional commit scope, e.g. api (requires --type)",
+			},
+			&ufcli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Allow committing on a protected branch (asks for confirmation)",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			message, err := conventionalMessage(c.String("type"), c.String("scope"), c.String("message"))
+			if err != nil {
+				return err
+			}
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			currentBranch, err := getCurrentBranch(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get current branch: %w", err)
+			}
+			if err := guardProtectedBranch(ctx, currentBranch, "commit", c.Bool("force")); err != nil {
+				return err
+			}
+
+			if c.Bool("all") {
+				if output, err := shell.Run(ctx, "git", "add", "-A"); err != nil {
+					return fmt.Errorf("failed to stage changes: %w\n%s", err, output)
+				}
+			}
+
+			// Nothing staged means there is nothing to commit. In a dry run
+			// --all stages nothing, so the check would be meaningless.
+			dryRunAll := c.Bool("all") && shell.IsDryRun(ctx)
+			if _, err := shell.Run(ctx, "git", "diff", "--cached", "--quiet"); err == nil && !dryRunAll {
+				return fmt.Errorf("nothing staged to commit; stage changes first or pass --all")
+			}
+
+			if err := shell.RunInteractive(ctx, "git", "commit", "-m", message); err != nil {
+				return fmt.Errorf("commit failed: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// conventionalMessage validates the message and prepends the
+// "type(scope): " prefix when a type is given
+func conventionalMessage(commitType, scope, message string) (string, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return "", fmt.Errorf("commit message is required (-m)")
+	}
+
+	if commitType == "" {
+		if scope != "" {
+			return "", fmt.Errorf("--scope requires --type")
+		}
+		return message, nil
+	}
+	if !slices.Contains(conventionalTypes, commitType) {
+		return "", fmt.Errorf("type must be one of %s, got %s", strings.Join(conventionalTypes, ", "), commitType)
+	}
+
+	if scope != "" {
+		return fmt.Sprintf("%s(%s): %s", commitType, scope, message), nil
+	}
+	return fmt.Sprintf("%s: %s", commitType, message), nil
+}
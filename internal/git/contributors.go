@@ -0,0 +1,108 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// contributor is one author's share of the commits touching a path
+type contributor struct {
+	Name    string
+	Commits int
+	Last    string // Date of the most recent commit, YYYY-MM-DD
+}
+
+// NewGitContributorsCmd ranks the authors of commits touching a path
+func NewGitContributorsCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "contributors",
+		Usage:     "Rank the authors of a file or directory by commit count",
+		ArgsUsage: "[path]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "since",
+				Usage: "Only count commits more recent than a date, e.g. '6 months ago' or 2024-01-01",
+			},
+			&ufcli.BoolFlag{
+				Name:  "email",
+				Usage: "Group authors by email instead of name",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			path := c.Args().First()
+			if path == "" {
+				path = "."
+			}
+
+			// %aN and %aE apply .mailmap, so one person's aliases are merged
+			key := "%aN"
+			if c.Bool("email") {
+				key = "%aE"
+			}
+			args := []string{"log", "--format=" + key + "%x1f%as"}
+			if since := c.String("since"); since != "" {
+				args = append(args, "--since="+since)
+			}
+			args = append(args, "--", path)
+
+			output, err := shell.Run(ctx, "git", args...)
+			if err != nil {
+				return fmt.Errorf("git log failed: %w\n%s", err, output)
+			}
+
+			contributors, total := tallyContributors(output)
+			if total == 0 {
+				fmt.Printf("No commits found for %s\n", path)
+				return nil
+			}
+
+			fmt.Printf("%-4s %-40s %8s %7s  %s\n", "#", "Author", "Commits", "Share", "Last")
+			fmt.Println(strings.Repeat("-", 80))
+			for i, contrib := range contributors {
+				share := float64(contrib.Commits) * 100 / float64(total)
+				fmt.Printf("%-4d %-40s %8d %6.1f%%  %s\n", i+1, contrib.Name, contrib.Commits, share, contrib.Last)
+			}
+			fmt.Printf("\n%d commit(s) by %d author(s) touching %s\n", total, len(contributors), path)
+			return nil
+		},
+	}
+}
+
+// tallyContributors counts commits per author from newest-first
+// "author\x1fdate" lines, ranked by commit count
+func tallyContributors(output string) ([]contributor, int) {
+	index := map[string]int{}
+	var contributors []contributor
+	total := 0
+	for _, line := range strings.Split(output, "\n") {
+		name, date, ok := strings.Cut(line, logFieldSep)
+		if !ok {
+			continue
+		}
+		total++
+		i, seen := index[name]
+		if !seen {
+			// The first commit seen is the most recent one
+			i = len(contributors)
+			index[name] = i
+			contributors = append(contributors, contributor{Name: name, Last: date})
+		}
+		contributors[i].Commits++
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		return contributors[i].Commits > contributors[j].Commits
+	})
+	return contributors, total
+}
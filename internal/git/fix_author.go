@@ -0,0 +1,157 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/christopher.carver/cc/internal/prompt"
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// NewGitFixAuthorCmd rewrites the author and committer of recent commits
+func NewGitFixAuthorCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "fix-author",
+		Usage: "Rewrite the author of the last N commits to the current (or given) identity",
+		Flags: []ufcli.Flag{
+			&ufcli.IntFlag{
+				Name:    "count",
+				Aliases: []string{"n"},
+				Usage:   "Number of commits to rewrite, counting back from HEAD",
+				Value:   1,
+			},
+			&ufcli.StringFlag{
+				Name:  "name",
+				Usage: "Author name to use (defaults to git config user.name)",
+			},
+			&ufcli.StringFlag{
+				Name:  "email",
+				Usage: "Author email to use (defaults to git config user.email)",
+			},
+			&ufcli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Rewrite commits even if they have already been pushed",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			count := c.Int("count")
+			if count < 1 {
+				return fmt.Errorf("count must be at least 1, got %d", count)
+			}
+
+			total, err := countCommits(ctx, "--first-parent", "HEAD")
+			if err != nil {
+				return err
+			}
+			if count > total {
+				return fmt.Errorf("only %d commit(s) exist on this branch, cannot rewrite %d", total, count)
+			}
+
+			name, email, err := resolveIdentity(ctx, c.String("name"), c.String("email"))
+			if err != nil {
+				return err
+			}
+
+			// Rewriting requires a clean tree
+			hasChanges, err := hasUncommittedChanges(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to check for uncommitted changes: %w", err)
+			}
+			if hasChanges {
+				return fmt.Errorf("uncommitted changes detected. Please commit or stash before rewriting history")
+			}
+
+			// Commits reachable from a remote have been shared with others
+			rangeSpec := "HEAD~" + strconv.Itoa(count) + "..HEAD"
+			if count == total {
+				rangeSpec = "HEAD"
+			}
+			rewritten, err := countCommits(ctx, rangeSpec)
+			if err != nil {
+				return err
+			}
+			unpushed, err := countCommits(ctx, rangeSpec, "--not", "--remotes")
+			if err != nil {
+				return err
+			}
+			if unpushed != rewritten {
+				if !c.Bool("force") {
+					return fmt.Errorf("some of the last %d commit(s) are already pushed; rewriting them requires a force push (use --force to continue anyway)", count)
+				}
+				fmt.Println("⚠️  WARNING: some of these commits are already pushed.")
+				fmt.Println("⚠️  Rewriting them changes their hashes and will require a force push.")
+				confirmed, err := prompt.Confirm("Rewrite pushed commits?")
+				if err != nil {
+					return fmt.Errorf("error reading input: %w", err)
+				}
+				if !confirmed {
+					return fmt.Errorf("fix-author cancelled")
+				}
+			}
+
+			fmt.Printf("Rewriting %d commit(s) as %s <%s>...\n", count, name, email)
+
+			// --reset-author takes the author from these variables, and the
+			// committer variables make the rewritten commits consistent
+			env := []string{
+				"GIT_AUTHOR_NAME=" + name,
+				"GIT_AUTHOR_EMAIL=" + email,
+				"GIT_COMMITTER_NAME=" + name,
+				"GIT_COMMITTER_EMAIL=" + email,
+			}
+			amend := []string{"commit", "--amend", "--no-edit", "--reset-author", "--no-verify"}
+
+			if count == 1 {
+				if err := shell.RunInteractiveWithEnv(ctx, env, "git", amend...); err != nil {
+					return fmt.Errorf("failed to amend commit: %w", err)
+				}
+			} else {
+				args := []string{"rebase", "--rebase-merges", "--exec", "git commit --amend --no-edit --reset-author --no-verify"}
+				if count == total {
+					args = append(args, "--root")
+				} else {
+					args = append(args, "HEAD~"+strconv.Itoa(count))
+				}
+				if err := shell.RunInteractiveWithEnv(ctx, env, "git", args...); err != nil {
+					return fmt.Errorf("rebase failed: %w (run 'git rebase --abort' to restore the original commits)", err)
+				}
+			}
+
+			fmt.Printf("✓ Rewrote the author of %d commit(s)\n", count)
+			return nil
+		},
+	}
+}
+
+// resolveIdentity fills in a missing name or email from git config
+func resolveIdentity(ctx context.Context, name, email string) (string, string, error) {
+	if name == "" {
+		name, _ = shell.Run(ctx, "git", "config", "user.name")
+	}
+	if email == "" {
+		email, _ = shell.Run(ctx, "git", "config", "user.email")
+	}
+	if name == "" || email == "" {
+		return "", "", fmt.Errorf("no identity configured; set git config user.name and user.email or pass --name and --email")
+	}
+	return name, email, nil
+}
+
+// countCommits returns the number of commits git rev-list selects with args
+func countCommits(ctx context.Context, args ...string) (int, error) {
+	output, err := shell.Run(ctx, "git", append([]string{"rev-list", "--count"}, args...)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits: %w", err)
+	}
+	return strconv.Atoi(output)
+}
@@ -3,32 +3,122 @@ package git
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/christopher.carver/cc/internal/repo"
 	"github.com/christopher.carver/cc/internal/shell"
 	ufcli "github.com/urfave/cli/v2"
 )
 
-// NewGitCmd creates the git command
+// NewGitCmd creates the git command.
+// Like git itself, every subcommand accepts -C/--dir to operate on a
+// repository other than the current directory.
 func NewGitCmd() *ufcli.Command {
-	return &ufcli.Command{
+	return withDirFlag(&ufcli.Command{
 		Name:  "git",
 		Usage: "Git operations and shortcuts",
 		Subcommands: []*ufcli.Command{
 			NewGitBranchCmd(),
 			NewGitRebaseCmd(),
 			NewGitCleanCmd(),
+			NewGitCleanupCmd(),
 			NewGitStatusCmd(),
+			NewGitInstallHooksCmd(),
+			NewGitWorktreeCmd(),
+			NewGitAddPatchCmd(),
+			NewGitCommitCmd(),
+			NewGitShowPRCmd(),
+			NewGitBlamePRCmd(),
+			NewGitSquashPreviewCmd(),
+			NewGitRebaseStatusCmd(),
+			NewGitLogCmd(),
+			NewGitContributorsCmd(),
+			NewGitProtectCmd(),
+			NewGitFixAuthorCmd(),
+			NewGitReleaseCmd(),
+			NewGitPullCmd(),
+			NewGitSyncCmd(),
+			NewGitInitRepoCmd(),
 		},
+	})
+}
+
+// withDirFlag adds the -C/--dir flag to cmd and all of its subcommands
+func withDirFlag(cmd *ufcli.Command) *ufcli.Command {
+	cmd.Flags = append(cmd.Flags, &ufcli.StringFlag{
+		Name:    "dir",
+		Aliases: []string{"C"},
+		Usage:   "Run as if cc was started in `PATH`",
+	})
+
+	before := cmd.Before
+	cmd.Before = func(c *ufcli.Context) error {
+		if err := applyDirFlag(c); err != nil {
+			return err
+		}
+		if before != nil {
+			return before(c)
+		}
+		return nil
+	}
+
+	for _, sub := range cmd.Subcommands {
+		withDirFlag(sub)
+	}
+	return cmd
+}
+
+// applyDirFlag points the command's context at the --dir directory, if set
+func applyDirFlag(c *ufcli.Context) error {
+	dir := c.String("dir")
+	if dir == "" {
+		return nil
 	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("invalid directory %s: %w", dir, err)
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	c.Context = shell.WithDir(c.Context, absDir)
+	return nil
 }
 
-// NewGitBranchCmd creates a new branch from main/master
+// NewGitBranchCmd creates a new branch from main/master, or deletes one with --delete
 func NewGitBranchCmd() *ufcli.Command {
 	return &ufcli.Command{
 		Name:      "branch",
-		Usage:     "Create a new branch from clean main/master",
+		Usage:     "Create a new branch from clean main/master, or delete one with --delete",
 		ArgsUsage: "<branch-name>",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:    "delete",
+				Aliases: []string{"d"},
+				Usage:   "Delete the branch instead (never the current or default branch)",
+			},
+			&ufcli.BoolFlag{
+				Name:  "remote",
+				Usage: "With --delete, also delete the branch on origin",
+			},
+			&ufcli.BoolFlag{
+				Name:  "force",
+				Usage: "With --delete, delete the branch even if it is not fully merged",
+			},
+			&ufcli.BoolFlag{
+				Name:  "no-stash",
+				Usage: "Abort if the working tree is dirty instead of auto-stashing",
+			},
+			&ufcli.BoolFlag{
+				Name:  "keep-changes",
+				Usage: "Create the branch from the current HEAD, carrying uncommitted changes",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			if c.NArg() < 1 {
 				return fmt.Errorf("branch name is required")
@@ -36,11 +126,33 @@ func NewGitBranchCmd() *ufcli.Command {
 			branchName := c.Args().First()
 			ctx := c.Context
 
+			if c.Bool("no-stash") && c.Bool("keep-changes") {
+				return fmt.Errorf("--no-stash and --keep-changes cannot be used together")
+			}
+
 			// Check if we're in a git repo
 			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
 				return fmt.Errorf("not in a git repository")
 			}
 
+			if c.Bool("delete") {
+				return deleteBranch(ctx, branchName, c.Bool("remote"), c.Bool("force"))
+			}
+			if c.Bool("remote") || c.Bool("force") {
+				return fmt.Errorf("--remote and --force require --delete")
+			}
+
+			// Branch off the current HEAD, leaving uncommitted changes in place
+			if c.Bool("keep-changes") {
+				fmt.Printf("Creating and checking out branch '%s' from current HEAD...\n", branchName)
+				if _, err := shell.Run(ctx, "git", "checkout", "-b", branchName); err != nil {
+					return fmt.Errorf("failed to create branch: %w", err)
+				}
+				repo.InvalidateCurrentBranch(ctx)
+				fmt.Printf("✓ Successfully created branch '%s' with your uncommitted changes\n", branchName)
+				return nil
+			}
+
 			// Get the default branch (main or master)
 			defaultBranch, err := getDefaultBranch(ctx)
 			if err != nil {
@@ -55,15 +167,25 @@ func NewGitBranchCmd() *ufcli.Command {
 				return fmt.Errorf("failed to check for uncommitted changes: %w", err)
 			}
 
+			if hasChanges && c.Bool("no-stash") {
+				return fmt.Errorf("uncommitted changes detected. Please commit or stash them, or use --keep-changes")
+			}
+
 			if hasChanges {
+				origHead, err := shell.Run(ctx, "git", "rev-parse", "HEAD")
+				if err != nil {
+					return fmt.Errorf("failed to resolve HEAD: %w", err)
+				}
 				fmt.Println("Stashing uncommitted changes...")
-				if _, err := shell.Run(ctx, "git", "stash"); err != nil {
+				// -u matches hasUncommittedChanges, which counts untracked files
+				stashBefore, _ := shell.Run(ctx, "git", "rev-parse", "-q", "--verify", "refs/stash")
+				if _, err := shell.Run(ctx, "git", "stash", "push", "-u"); err != nil {
 					return fmt.Errorf("failed to stash changes: %w", err)
 				}
-				defer func() {
-					fmt.Println("Restoring stashed changes...")
-					shell.Run(ctx, "git", "stash", "pop")
-				}()
+				// Only pop a stash made here, never an older unrelated one
+				if stashAfter, _ := shell.Run(ctx, "git", "rev-parse", "-q", "--verify", "refs/stash"); stashAfter != "" && stashAfter != stashBefore {
+					defer restoreStash(ctx, origHead)
+				}
 			}
 
 			// Checkout default branch and pull latest
@@ -71,6 +193,7 @@ func NewGitBranchCmd() *ufcli.Command {
 			if _, err := shell.Run(ctx, "git", "checkout", defaultBranch); err != nil {
 				return fmt.Errorf("failed to checkout %s: %w", defaultBranch, err)
 			}
+			repo.InvalidateCurrentBranch(ctx)
 			if _, err := shell.Run(ctx, "git", "pull"); err != nil {
 				return fmt.Errorf("failed to pull latest: %w", err)
 			}
@@ -80,6 +203,7 @@ func NewGitBranchCmd() *ufcli.Command {
 			if _, err := shell.Run(ctx, "git", "checkout", "-b", branchName); err != nil {
 				return fmt.Errorf("failed to create branch: %w", err)
 			}
+			repo.InvalidateCurrentBranch(ctx)
 
 			fmt.Printf("✓ Successfully created branch '%s' from '%s'\n", branchName, defaultBranch)
 			return nil
@@ -87,12 +211,67 @@ func NewGitBranchCmd() *ufcli.Command {
 	}
 }
 
+// restoreStash pops the stash made before switching branches. If files in
+// the stash changed between origHead and the new HEAD the pop is skipped,
+// since it would likely conflict; either way, a stash that can't be applied
+// is left in place with instructions for recovering it.
+func restoreStash(ctx context.Context, origHead string) {
+	stashed, err := shell.Run(ctx, "git", "stash", "show", "--name-only", "stash@{0}")
+	if err == nil && stashed != "" {
+		changed, err := shell.Run(ctx, "git", "diff", "--name-only", origHead, "HEAD")
+		if err == nil && changed != "" {
+			var overlap []string
+			for _, file := range strings.Split(stashed, "\n") {
+				if slices.Contains(strings.Split(changed, "\n"), file) {
+					overlap = append(overlap, file)
+				}
+			}
+			if len(overlap) > 0 {
+				fmt.Println("⚠️  Not restoring stashed changes: these files also changed on the new branch:")
+				for _, file := range overlap {
+					fmt.Printf("    %s\n", file)
+				}
+				printStashRecovery()
+				return
+			}
+		}
+	}
+
+	fmt.Println("Restoring stashed changes...")
+	if output, err := shell.Run(ctx, "git", "stash", "pop"); err != nil {
+		fmt.Println("⚠️  Restoring stashed changes failed:")
+		fmt.Println(output)
+		if conflicts, err := getConflictedFiles(ctx); err == nil && len(conflicts) > 0 {
+			fmt.Println("\nResolve the conflicts in these files, then run 'git stash drop':")
+			for _, file := range conflicts {
+				fmt.Printf("    %s\n", file)
+			}
+		}
+		printStashRecovery()
+	}
+}
+
+// printStashRecovery explains how to get stashed changes back
+func printStashRecovery() {
+	fmt.Println("\nYour changes are still saved in the stash (stash@{0}). To recover them:")
+	fmt.Println("    git stash list           # find the entry")
+	fmt.Println("    git stash show -p        # review the changes")
+	fmt.Println("    git stash pop            # apply and drop it once the tree is ready")
+}
+
 // NewGitRebaseCmd rebases current branch onto target branch with 3-step workflow
 func NewGitRebaseCmd() *ufcli.Command {
 	return &ufcli.Command{
 		Name:      "rebase",
-		Usage:     "Rebase current branch onto target branch (fetch, rebase, force push)",
+		Usage:     "Rebase current branch onto target branch (fetch, rebase, force push with lease)",
 		ArgsUsage: "<target-branch>",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Push with --force instead of --force-with-lease, overwriting the remote even if it moved",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			if c.NArg() < 1 {
 				return fmt.Errorf("target branch is required")
@@ -135,14 +314,25 @@ func NewGitRebaseCmd() *ufcli.Command {
 
 			// Step 2: Rebase onto target branch
 			fmt.Printf("Step 2: Rebasing onto '%s'...\n", targetBranch)
-			if err := shell.RunInteractive(ctx, "git", "rebase", targetBranch); err != nil {
+			err = shell.RunInteractive(ctx, "git", "rebase", targetBranch)
+			// A stopped rebase leaves HEAD detached
+			repo.InvalidateCurrentBranch(ctx)
+			if err != nil {
 				return fmt.Errorf("rebase failed: %w", err)
 			}
 
-			// Step 3: Force push to origin
-			fmt.Printf("Step 3: Force pushing '%s' to origin...\n", currentBranch)
-			if _, err := shell.Run(ctx, "git", "push", "-f", "origin", currentBranch); err != nil {
-				return fmt.Errorf("failed to force push: %w", err)
+			// Step 3: Force push to origin. The lease rejects the push if someone
+			// else pushed to the branch since our last fetch.
+			forceFlag := "--force-with-lease"
+			if c.Bool("force") {
+				forceFlag = "--force"
+			}
+			fmt.Printf("Step 3: Force pushing '%s' to origin (%s)...\n", currentBranch, forceFlag)
+			if output, err := shell.Run(ctx, "git", "push", forceFlag, "origin", currentBranch); err != nil {
+				if forceFlag == "--force-with-lease" && strings.Contains(output, "stale info") {
+					return fmt.Errorf("origin/%s has commits you haven't fetched; review them, or re-run with --force to overwrite them\n%s", currentBranch, output)
+				}
+				return fmt.Errorf("failed to force push: %w\n%s", err, output)
 			}
 
 			fmt.Printf("✓ Successfully rebased and pushed '%s' onto '%s'\n", currentBranch, targetBranch)
@@ -209,6 +399,13 @@ func NewGitStatusCmd() *ufcli.Command {
 	return &ufcli.Command{
 		Name:  "status",
 		Usage: "Show enhanced git status with branch info",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:    "all",
+				Aliases: []string{"a"},
+				Usage:   "List every local branch with its upstream ahead/behind counts",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 
@@ -217,6 +414,10 @@ func NewGitStatusCmd() *ufcli.Command {
 				return fmt.Errorf("not in a git repository")
 			}
 
+			if c.Bool("all") {
+				return printBranchOverview(ctx)
+			}
+
 			// Get current branch
 			currentBranch, err := getCurrentBranch(ctx)
 			if err != nil {
@@ -231,6 +432,9 @@ func NewGitStatusCmd() *ufcli.Command {
 
 			// Show branch info
 			fmt.Printf("Current branch: %s\n", currentBranch)
+			if protected, err := isProtectedBranch(ctx, currentBranch); err == nil && protected {
+				fmt.Printf("  ⚠ '%s' is protected; commit on a feature branch instead\n", currentBranch)
+			}
 			if currentBranch != defaultBranch {
 				// Check if branch is ahead/behind
 				ahead, behind, err := getBranchStatus(ctx, currentBranch, defaultBranch)
@@ -263,32 +467,185 @@ func NewGitStatusCmd() *ufcli.Command {
 	}
 }
 
+// NewGitAddPatchCmd interactively stages hunks with git add -p
+func NewGitAddPatchCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "add-patch",
+		Usage:     "Interactively stage hunks (git add -p), optionally committing afterwards",
+		ArgsUsage: "[paths...]",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "commit",
+				Usage: "Commit the staged hunks once staging is done",
+			},
+			&ufcli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Allow --commit on a protected branch (asks for confirmation)",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			// Refuse up front so hunks aren't staged for a commit that can't happen
+			if c.Bool("commit") {
+				currentBranch, err := getCurrentBranch(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get current branch: %w", err)
+				}
+				if err := guardProtectedBranch(ctx, currentBranch, "commit", c.Bool("force")); err != nil {
+					return err
+				}
+			}
+
+			// With no paths, git add -p walks every change
+			args := append([]string{"add", "-p"}, c.Args().Slice()...)
+			if err := shell.RunInteractive(ctx, "git", args...); err != nil {
+				return fmt.Errorf("git add -p failed: %w", err)
+			}
+
+			if !c.Bool("commit") {
+				return nil
+			}
+
+			// Nothing staged means there is nothing to commit
+			if _, err := shell.Run(ctx, "git", "diff", "--cached", "--quiet"); err == nil {
+				fmt.Println("No hunks staged, skipping commit")
+				return nil
+			}
+			if err := shell.RunInteractive(ctx, "git", "commit"); err != nil {
+				return fmt.Errorf("commit failed: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// NewGitSquashPreviewCmd shows what squash-merging the current branch would produce
+func NewGitSquashPreviewCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "squash-preview",
+		Usage:     "Preview the combined diff and a squash commit message (read-only)",
+		ArgsUsage: "[base]",
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			base := c.Args().First()
+			if base == "" {
+				defaultBranch, err := getDefaultBranch(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to determine default branch: %w", err)
+				}
+				base = defaultBranch
+			}
+			rangeSpec := base + "...HEAD"
+
+			subjects, err := shell.Run(ctx, "git", "log", "--reverse", "--format=%s", base+"..HEAD")
+			if err != nil {
+				return fmt.Errorf("failed to list commits: %w", err)
+			}
+			if subjects == "" {
+				fmt.Printf("No commits on this branch relative to '%s'\n", base)
+				return nil
+			}
+
+			stat, err := shell.Run(ctx, "git", "diff", "--stat", rangeSpec)
+			if err != nil {
+				return fmt.Errorf("failed to get diff stat: %w", err)
+			}
+			diff, err := shell.Run(ctx, "git", "diff", rangeSpec)
+			if err != nil {
+				return fmt.Errorf("failed to get diff: %w", err)
+			}
+
+			fmt.Printf("Changes relative to '%s':\n\n%s\n\n", base, stat)
+			fmt.Println(diff)
+
+			fmt.Println("\n" + strings.Repeat("=", 80))
+			fmt.Println("SUGGESTED SQUASH COMMIT MESSAGE")
+			fmt.Println(strings.Repeat("=", 80))
+			fmt.Println(buildSquashMessage(strings.Split(subjects, "\n")))
+			fmt.Println(strings.Repeat("=", 80))
+			return nil
+		},
+	}
+}
+
 // Helper functions
 
-func getCurrentBranch(ctx context.Context) (string, error) {
-	output, err := shell.Run(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD")
-	if err != nil {
-		return "", err
+// buildSquashMessage assembles a squash commit message from commit subjects,
+// using the first subject as the title and listing every subject in the body
+func buildSquashMessage(subjects []string) string {
+	if len(subjects) == 1 {
+		return subjects[0]
+	}
+
+	var b strings.Builder
+	b.WriteString(subjects[0] + "\n")
+	for _, subject := range subjects {
+		b.WriteString("\n* " + subject)
 	}
-	return output, nil
+	return b.String()
 }
 
-func getDefaultBranch(ctx context.Context) (string, error) {
-	// Try to get the default branch from remote
-	output, err := shell.Run(ctx, "git", "symbolic-ref", "refs/remotes/origin/HEAD")
-	if err == nil {
-		// Parse output like "refs/remotes/origin/main"
-		parts := strings.Split(output, "/")
-		if len(parts) > 0 {
-			return parts[len(parts)-1], nil
-		}
+// printBranchOverview prints every local branch with its upstream tracking state
+func printBranchOverview(ctx context.Context) error {
+	output, err := shell.Run(ctx, "git", "for-each-ref", "--format=%(refname:short)|%(upstream:short)|%(upstream:track)", "refs/heads")
+	if err != nil {
+		return fmt.Errorf("failed to list branches: %w", err)
 	}
 
-	// Fallback: check if main exists, otherwise use master
-	if _, err := shell.Run(ctx, "git", "show-ref", "--verify", "--quiet", "refs/heads/main"); err == nil {
-		return "main", nil
+	currentBranch, _ := getCurrentBranch(ctx)
+
+	fmt.Printf("  %-40s %-40s %-8s %-8s\n", "Branch", "Upstream", "Ahead", "Behind")
+	fmt.Println(strings.Repeat("-", 100))
+
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "|", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		branch, upstream, track := parts[0], parts[1], parts[2]
+
+		marker := " "
+		if branch == currentBranch {
+			marker = "*"
+		}
+
+		switch {
+		case upstream == "":
+			fmt.Printf("%s %-40s %-40s %-8s %-8s\n", marker, branch, "local only", "-", "-")
+		case track == "[gone]":
+			fmt.Printf("%s %-40s %-40s %-8s %-8s\n", marker, branch, upstream+" (gone)", "-", "-")
+		default:
+			ahead, behind, err := getBranchStatus(ctx, branch, upstream)
+			if err != nil {
+				fmt.Printf("%s %-40s %-40s %-8s %-8s\n", marker, branch, upstream, "?", "?")
+				continue
+			}
+			fmt.Printf("%s %-40s %-40s %-8d %-8d\n", marker, branch, upstream, ahead, behind)
+		}
 	}
-	return "master", nil
+
+	return nil
+}
+
+func getCurrentBranch(ctx context.Context) (string, error) {
+	return repo.CurrentBranch(ctx)
+}
+
+func getDefaultBranch(ctx context.Context) (string, error) {
+	return repo.DefaultBranch(ctx)
 }
 
 func hasUncommittedChanges(ctx context.Context) (bool, error) {
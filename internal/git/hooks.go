@@ -0,0 +1,166 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// hookMarker identifies hook scripts written by cc so they can be safely
+// replaced or removed without touching user-authored hooks
+const hookMarker = "# Installed by cc"
+
+// preCommitHook format-checks the staged .tf files and validates every
+// directory containing one, blocking the commit on failure. Paths are read
+// line by line so names with spaces survive.
+const preCommitHook = `#!/bin/sh
+` + hookMarker + ` - run 'cc git install-hooks --pre-commit --uninstall' to remove
+set -e
+
+cd "$(git rev-parse --show-toplevel)"
+files=$(git diff --cached --name-only --diff-filter=ACMR -- '*.tf')
+if [ -z "$files" ]; then
+	exit 0
+fi
+
+printf '%s\n' "$files" | {
+	failed=0
+	while IFS= read -r file; do
+		if ! terraform fmt -check "$file" >/dev/null; then
+			echo "Needs formatting: $file"
+			failed=1
+		fi
+	done
+	if [ "$failed" -ne 0 ]; then
+		echo "Run 'cc terraform fmt --changed --stage' to fix"
+	fi
+	exit "$failed"
+}
+
+printf '%s\n' "$files" | while IFS= read -r file; do
+	dirname "$file"
+done | sort -u | while IFS= read -r dir; do
+	echo "Validating $dir..."
+	(cd "$dir" && cc terraform validate)
+done
+`
+
+// NewGitInstallHooksCmd installs or removes cc-managed git hooks
+func NewGitInstallHooksCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "install-hooks",
+		Usage: "Install git hooks that run terraform checks before committing",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "pre-commit",
+				Usage: "Install the pre-commit hook (terraform fmt --check and validate)",
+			},
+			&ufcli.BoolFlag{
+				Name:  "uninstall",
+				Usage: "Remove the hook and restore any backed-up hook",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			if !c.Bool("pre-commit") {
+				return fmt.Errorf("specify a hook to manage, e.g. --pre-commit")
+			}
+
+			hookPath, err := getHookPath(ctx, "pre-commit")
+			if err != nil {
+				return err
+			}
+
+			if shell.IsDryRun(ctx) {
+				if c.Bool("uninstall") {
+					fmt.Printf("Would remove %s\n", hookPath)
+				} else {
+					fmt.Printf("Would install %s\n", hookPath)
+				}
+				return nil
+			}
+
+			if c.Bool("uninstall") {
+				return uninstallHook(hookPath)
+			}
+			return installHook(hookPath, preCommitHook)
+		},
+	}
+}
+
+// getHookPath resolves the path of a named hook, honoring core.hooksPath
+// and worktrees via git itself
+func getHookPath(ctx context.Context, name string) (string, error) {
+	output, err := shell.Run(ctx, "git", "rev-parse", "--path-format=absolute", "--git-path", filepath.Join("hooks", name))
+	if err != nil {
+		return "", fmt.Errorf("failed to locate hooks directory: %w", err)
+	}
+	return output, nil
+}
+
+// installHook writes a hook script, backing up any existing hook not written by cc
+func installHook(hookPath, script string) error {
+	existing, err := os.ReadFile(hookPath)
+	switch {
+	case err == nil && !strings.Contains(string(existing), hookMarker):
+		backupPath := hookPath + ".bak"
+		if err := os.Rename(hookPath, backupPath); err != nil {
+			return fmt.Errorf("failed to back up existing hook: %w", err)
+		}
+		fmt.Printf("Backed up existing hook to %s\n", backupPath)
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("failed to read existing hook: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(hookPath), 0755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(hookPath, []byte(script), 0755); err != nil {
+		return fmt.Errorf("failed to write hook: %w", err)
+	}
+
+	fmt.Printf("✓ Installed %s\n", hookPath)
+	return nil
+}
+
+// uninstallHook removes a cc-managed hook and restores the backup if one exists
+func uninstallHook(hookPath string) error {
+	existing, err := os.ReadFile(hookPath)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Println("Hook is not installed")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read hook: %w", err)
+	}
+	if !strings.Contains(string(existing), hookMarker) {
+		return fmt.Errorf("%s was not installed by cc, refusing to remove it", hookPath)
+	}
+
+	if err := os.Remove(hookPath); err != nil {
+		return fmt.Errorf("failed to remove hook: %w", err)
+	}
+	fmt.Printf("✓ Removed %s\n", hookPath)
+
+	backupPath := hookPath + ".bak"
+	if _, err := os.Stat(backupPath); err == nil {
+		if err := os.Rename(backupPath, hookPath); err != nil {
+			return fmt.Errorf("failed to restore backed-up hook: %w", err)
+		}
+		fmt.Printf("✓ Restored previous hook from %s\n", backupPath)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// gitignoreCommon is included in every generated .gitignore
+const gitignoreCommon = `# OS and editor files
+.DS_Store
+Thumbs.db
+.idea/
+.vscode/
+*.swp
+`
+
+// gitignoreTemplates are the language-specific .gitignore entries for --lang
+var gitignoreTemplates = map[string]string{
+	"go": `
+# Go
+/bin/
+*.exe
+*.test
+*.out
+vendor/
+`,
+	"python": `
+# Python
+__pycache__/
+*.py[cod]
+.venv/
+venv/
+*.egg-info/
+dist/
+build/
+.pytest_cache/
+`,
+	"node": `
+# Node
+node_modules/
+dist/
+coverage/
+npm-debug.log*
+.env
+`,
+	"terraform": `
+# Terraform
+.terraform/
+*.tfstate
+*.tfstate.*
+*.tfplan
+crash.log
+override.tf
+override.tf.json
+*_override.tf
+*_override.tf.json
+.terraform.tfstate.lock.info
+`,
+}
+
+// NewGitInitRepoCmd bootstraps a new repository with a .gitignore and an initial commit
+func NewGitInitRepoCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "init-repo",
+		Usage:     "Create a repository on main with a .gitignore, README, and initial commit",
+		ArgsUsage: "[path]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "lang",
+				Usage: "Add .gitignore entries for a language: " + strings.Join(sortedTemplateNames(), ", "),
+			},
+			&ufcli.StringFlag{
+				Name:  "remote",
+				Usage: "Add this URL as the origin remote",
+			},
+			&ufcli.BoolFlag{
+				Name:  "force",
+				Usage: "Run even if the directory already contains a git repository",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			lang := c.String("lang")
+			if _, ok := gitignoreTemplates[lang]; lang != "" && !ok {
+				return fmt.Errorf("lang must be one of %s, got %s", strings.Join(sortedTemplateNames(), ", "), lang)
+			}
+
+			dir := c.Args().First()
+			if dir == "" {
+				dir = "."
+			}
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(shell.Dir(ctx), dir)
+			}
+			dir, err := filepath.Abs(dir)
+			if err != nil {
+				return fmt.Errorf("invalid path: %w", err)
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil && !c.Bool("force") {
+				return fmt.Errorf("%s already contains a git repository; pass --force to initialize it anyway", dir)
+			}
+
+			if !shell.IsDryRun(ctx) {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return fmt.Errorf("failed to create %s: %w", dir, err)
+				}
+			}
+
+			if output, err := shell.RunWithDir(ctx, dir, "git", "init", "-b", "main"); err != nil {
+				return fmt.Errorf("git init failed: %w\n%s", err, output)
+			}
+			fmt.Printf("✓ Initialized repository in %s on branch main\n", dir)
+
+			gitignore := gitignoreCommon + gitignoreTemplates[lang]
+			if err := writeIfMissing(ctx, dir, ".gitignore", gitignore); err != nil {
+				return err
+			}
+			readme := fmt.Sprintf("# %s\n", filepath.Base(dir))
+			if err := writeIfMissing(ctx, dir, "README.md", readme); err != nil {
+				return err
+			}
+
+			if output, err := shell.RunWithDir(ctx, dir, "git", "add", ".gitignore", "README.md"); err != nil {
+				return fmt.Errorf("failed to stage files: %w\n%s", err, output)
+			}
+			// A re-initialized repository (--force) may already have history
+			if _, err := shell.RunWithDir(ctx, dir, "git", "rev-parse", "--verify", "--quiet", "HEAD"); err == nil {
+				fmt.Println("Repository already has commits, skipping the initial commit")
+			} else {
+				if output, err := shell.RunWithDir(ctx, dir, "git", "commit", "--allow-empty", "-m", "Initial commit"); err != nil {
+					return fmt.Errorf("failed to create initial commit: %w\n%s", err, output)
+				}
+				fmt.Println("✓ Created initial commit")
+			}
+
+			if remote := c.String("remote"); remote != "" {
+				if output, err := shell.RunWithDir(ctx, dir, "git", "remote", "add", "origin", remote); err != nil {
+					return fmt.Errorf("failed to add remote: %w\n%s", err, output)
+				}
+				fmt.Printf("✓ Added remote origin: %s\n", remote)
+			}
+			return nil
+		},
+	}
+}
+
+// writeIfMissing creates dir/name with content unless it already exists
+func writeIfMissing(ctx context.Context, dir, name, content string) error {
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		fmt.Printf("⚠️  %s already exists, leaving it unchanged\n", name)
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to check %s: %w", name, err)
+	}
+
+	if shell.IsDryRun(ctx) {
+		fmt.Printf("Would write %s\n", path)
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	fmt.Printf("✓ Wrote %s\n", name)
+	return nil
+}
+
+// sortedTemplateNames returns the --lang values in alphabetical order
+func sortedTemplateNames() []string {
+	names := make([]string, 0, len(gitignoreTemplates))
+	for name := range gitignoreTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
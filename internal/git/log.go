@@ -0,0 +1,141 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// Field and record separators for the json preset's pretty format.
+// ASCII unit/record separators never appear in commit metadata.
+const (
+	logFieldSep  = "\x1f"
+	logRecordSep = "\x1e"
+)
+
+// logPrettyJSON is the --pretty format parsed by the json preset
+const logPrettyJSON = "format:%H%x1f%an%x1f%ae%x1f%aI%x1f%s%x1f%b%x1e"
+
+// logPresets maps the human-readable presets to git log arguments
+var logPresets = map[string][]string{
+	"oneline": {"--oneline", "--decorate"},
+	"full":    {"--pretty=fuller", "--stat"},
+	"graph":   {"--graph", "--oneline", "--decorate", "--all"},
+}
+
+// commitEntry is a commit as emitted by the json preset
+type commitEntry struct {
+	Hash    string `json:"hash"`
+	Author  string `json:"author"`
+	Email   string `json:"email"`
+	Date    string `json:"date"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// NewGitLogCmd shows history using a named output preset
+func NewGitLogCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "log",
+		Usage:     "Show commit history using a preset format (oneline, full, graph, json)",
+		ArgsUsage: "[revision-range] [-- paths...]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "format",
+				Usage: "Output preset: oneline, full, graph, or json",
+				Value: "oneline",
+			},
+			&ufcli.IntFlag{
+				Name:    "count",
+				Aliases: []string{"n"},
+				Usage:   "Limit the number of commits shown (0 for no limit)",
+			},
+			&ufcli.StringFlag{
+				Name:  "since",
+				Usage: "Only show commits more recent than a date, e.g. '2 weeks ago' or 2024-01-01",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			count := c.Int("count")
+			if count < 0 {
+				return fmt.Errorf("count must not be negative, got %d", count)
+			}
+
+			var filters []string
+			if count > 0 {
+				filters = append(filters, "--max-count="+strconv.Itoa(count))
+			}
+			if since := c.String("since"); since != "" {
+				filters = append(filters, "--since="+since)
+			}
+			filters = append(filters, c.Args().Slice()...)
+
+			format := c.String("format")
+			if format == "json" {
+				return printLogJSON(ctx, filters)
+			}
+
+			preset, ok := logPresets[format]
+			if !ok {
+				return fmt.Errorf("format must be one of 'oneline', 'full', 'graph', or 'json', got %s", format)
+			}
+
+			args := append([]string{"log"}, preset...)
+			args = append(args, filters...)
+			return shell.RunInteractive(ctx, "git", args...)
+		},
+	}
+}
+
+// printLogJSON writes the matching commits to stdout as a JSON array
+func printLogJSON(ctx context.Context, filters []string) error {
+	args := append([]string{"log", "--pretty=" + logPrettyJSON}, filters...)
+	output, err := shell.Run(ctx, "git", args...)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w\n%s", err, output)
+	}
+
+	commits := parseLogJSON(output)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(commits)
+}
+
+// parseLogJSON splits delimiter-separated git log output into commits
+func parseLogJSON(output string) []commitEntry {
+	commits := []commitEntry{}
+	for _, record := range strings.Split(output, logRecordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, logFieldSep, 6)
+		if len(fields) < 6 {
+			continue
+		}
+		commits = append(commits, commitEntry{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Email:   fields[2],
+			Date:    fields[3],
+			Subject: fields[4],
+			Body:    strings.TrimSpace(fields[5]),
+		})
+	}
+	return commits
+}
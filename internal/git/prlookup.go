@@ -0,0 +1,136 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// pullRequest is the subset of gh's PR JSON that cc displays
+type pullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+// prNumberPattern matches PR references left in merge and squash commit
+// subjects, e.g. "Merge pull request #123" or "Fix thing (#123)"
+var prNumberPattern = regexp.MustCompile(`(?:Merge pull request #(\d+)|\(#(\d+)\)$)`)
+
+// NewGitShowPRCmd finds the pull request that introduced a commit
+func NewGitShowPRCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "show-pr",
+		Usage:     "Show the pull request that introduced a commit",
+		ArgsUsage: "<commit>",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "web",
+				Usage: "Open the pull request in the browser",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 1 {
+				return fmt.Errorf("commit is required")
+			}
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+			if !shell.CommandExists("gh") {
+				return fmt.Errorf("gh is not installed. Install it with: brew install gh")
+			}
+
+			sha, err := shell.Run(ctx, "git", "rev-parse", c.Args().First()+"^{commit}")
+			if err != nil {
+				return fmt.Errorf("unknown commit: %s", c.Args().First())
+			}
+
+			pr, err := findPRForCommit(ctx, sha)
+			if err != nil {
+				return err
+			}
+			if pr == nil {
+				fmt.Printf("No pull request found for %s\n", shortSHA(sha))
+				return nil
+			}
+
+			printPR(sha, pr)
+			if c.Bool("web") {
+				return shell.RunInteractive(ctx, "gh", "pr", "view", strconv.Itoa(pr.Number), "--web")
+			}
+			return nil
+		},
+	}
+}
+
+// findPRForCommit looks up the pull request containing a commit via gh,
+// falling back to a PR number recorded in the commit subject. It returns
+// nil when no pull request can be found.
+func findPRForCommit(ctx context.Context, sha string) (*pullRequest, error) {
+	output, err := shell.Run(ctx, "gh", "pr", "list", "--state", "all", "--search", sha, "--json", "number,title,url,author", "--limit", "1")
+	if err != nil {
+		return nil, fmt.Errorf("gh pr search failed: %w\n%s", err, output)
+	}
+
+	var prs []pullRequest
+	if err := json.Unmarshal([]byte(output), &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse gh output: %w", err)
+	}
+	if len(prs) > 0 {
+		return &prs[0], nil
+	}
+
+	// Fallback: merge and squash commits usually name the PR in their subject
+	subject, err := shell.Run(ctx, "git", "log", "-1", "--format=%s", sha)
+	if err != nil {
+		return nil, nil
+	}
+	matches := prNumberPattern.FindStringSubmatch(subject)
+	if matches == nil {
+		return nil, nil
+	}
+	number := matches[1]
+	if number == "" {
+		number = matches[2]
+	}
+
+	output, err = shell.Run(ctx, "gh", "pr", "view", number, "--json", "number,title,url,author")
+	if err != nil {
+		return nil, nil
+	}
+	var pr pullRequest
+	if err := json.Unmarshal([]byte(output), &pr); err != nil {
+		return nil, fmt.Errorf("failed to parse gh output: %w", err)
+	}
+	return &pr, nil
+}
+
+// printPR prints a pull request's details for a commit
+func printPR(sha string, pr *pullRequest) {
+	fmt.Printf("Commit: %s\n", shortSHA(sha))
+	fmt.Printf("PR:     #%d %s\n", pr.Number, pr.Title)
+	if pr.Author.Login != "" {
+		fmt.Printf("Author: %s\n", pr.Author.Login)
+	}
+	fmt.Printf("URL:    %s\n", pr.URL)
+}
+
+// shortSHA abbreviates a commit hash to 12 characters for display, leaving
+// already-short hashes as they are
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
@@ -0,0 +1,135 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/prompt"
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// protectedConfigKey is the repo-local git config key holding protected branch names
+const protectedConfigKey = "cc.protected"
+
+// NewGitProtectCmd records branches that cc should refuse to commit or push to directly
+func NewGitProtectCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "protect",
+		Usage:     "Guard branches against direct commits and pushes (lists protected branches with no args)",
+		ArgsUsage: "[branch...]",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "remove",
+				Usage: "Stop protecting the given branches",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			protected, err := getProtectedBranches(ctx)
+			if err != nil {
+				return err
+			}
+
+			if c.NArg() == 0 {
+				if c.Bool("remove") {
+					return fmt.Errorf("at least one branch is required with --remove")
+				}
+				if len(protected) == 0 {
+					fmt.Println("No protected branches")
+					return nil
+				}
+				fmt.Println("Protected branches:")
+				for _, branch := range protected {
+					fmt.Printf("  %s\n", branch)
+				}
+				return nil
+			}
+
+			for _, branch := range c.Args().Slice() {
+				isProtected := slices.Contains(protected, branch)
+
+				if c.Bool("remove") {
+					if !isProtected {
+						fmt.Printf("%s is not protected\n", branch)
+						continue
+					}
+					// The value is a regex for --unset; match the name exactly
+					pattern := "^" + regexp.QuoteMeta(branch) + "$"
+					if _, err := shell.Run(ctx, "git", "config", "--local", "--unset-all", protectedConfigKey, pattern); err != nil {
+						return fmt.Errorf("failed to unprotect %s: %w", branch, err)
+					}
+					fmt.Printf("✓ %s is no longer protected\n", branch)
+					continue
+				}
+
+				if isProtected {
+					fmt.Printf("%s is already protected\n", branch)
+					continue
+				}
+				if _, err := shell.Run(ctx, "git", "config", "--local", "--add", protectedConfigKey, branch); err != nil {
+					return fmt.Errorf("failed to protect %s: %w", branch, err)
+				}
+				protected = append(protected, branch)
+				fmt.Printf("✓ %s is now protected\n", branch)
+			}
+			return nil
+		},
+	}
+}
+
+// getProtectedBranches returns the branches recorded with `cc git protect`
+func getProtectedBranches(ctx context.Context) ([]string, error) {
+	output, err := shell.Run(ctx, "git", "config", "--get-all", protectedConfigKey)
+	if err != nil {
+		// git config exits 1 when the key is not set
+		if shell.ExitCode(err) == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read protected branches: %w", err)
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// isProtectedBranch reports whether branch has been protected in this repository
+func isProtectedBranch(ctx context.Context, branch string) (bool, error) {
+	protected, err := getProtectedBranches(ctx)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(protected, branch), nil
+}
+
+// guardProtectedBranch refuses to continue on a protected branch unless force
+// is set and the user confirms. action describes the operation, e.g. "commit".
+func guardProtectedBranch(ctx context.Context, branch, action string, force bool) error {
+	protected, err := isProtectedBranch(ctx, branch)
+	if err != nil {
+		return err
+	}
+	if !protected {
+		return nil
+	}
+
+	if !force {
+		return fmt.Errorf("'%s' is protected; refusing to %s directly (use --force to override)", branch, action)
+	}
+
+	confirmed, err := prompt.Confirm(fmt.Sprintf("'%s' is protected. Really %s directly to it?", branch, action))
+	if err != nil {
+		return fmt.Errorf("error reading input: %w", err)
+	}
+	if !confirmed {
+		return fmt.Errorf("%s cancelled", action)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// NewGitPullCmd pulls the current branch's upstream, rebasing by default
+func NewGitPullCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "pull",
+		Usage: "Pull the upstream branch with --rebase --autostash (team policy)",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "merge",
+				Usage: "Merge the upstream instead of rebasing onto it",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			currentBranch, err := getCurrentBranch(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get current branch: %w", err)
+			}
+
+			upstream, err := shell.Run(ctx, "git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+			if err != nil {
+				return fmt.Errorf("'%s' has no upstream branch. Set one with: git push -u origin %s", currentBranch, currentBranch)
+			}
+
+			// Remote names may contain "/", so ask git rather than split the upstream
+			remote, err := shell.Run(ctx, "git", "config", "--get", "branch."+currentBranch+".remote")
+			if err != nil {
+				return fmt.Errorf("failed to find the remote for '%s': %w", currentBranch, err)
+			}
+			if _, err := shell.Run(ctx, "git", "fetch", remote); err != nil {
+				return fmt.Errorf("failed to fetch %s: %w", remote, err)
+			}
+
+			if err := printUpstreamStatus(ctx, "Before", upstream); err != nil {
+				return err
+			}
+
+			args := []string{"pull", "--rebase", "--autostash"}
+			if c.Bool("merge") {
+				args = []string{"pull", "--no-rebase"}
+			}
+			fmt.Printf("Pulling %s into '%s'...\n", upstream, currentBranch)
+			if err := shell.RunInteractive(ctx, "git", args...); err != nil {
+				return fmt.Errorf("pull failed: %w", err)
+			}
+
+			return printUpstreamStatus(ctx, "After", upstream)
+		},
+	}
+}
+
+// printUpstreamStatus prints how far HEAD is ahead of and behind upstream
+func printUpstreamStatus(ctx context.Context, label, upstream string) error {
+	output, err := shell.Run(ctx, "git", "rev-list", "--left-right", "--count", "HEAD..."+upstream)
+	if err != nil {
+		return fmt.Errorf("failed to compare with %s: %w", upstream, err)
+	}
+
+	var ahead, behind int
+	fmt.Sscanf(output, "%d %d", &ahead, &behind)
+	fmt.Printf("%s: %d ahead, %d behind %s\n", label, ahead, behind, upstream)
+	return nil
+}
@@ -0,0 +1,236 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/prompt"
+	"github.com/christopher.carver/cc/internal/repo"
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// rebaseState describes an in-progress rebase
+type rebaseState struct {
+	InProgress bool
+	Current    int // Step currently being applied (1-based)
+	Total      int // Total number of steps in the rebase
+}
+
+// Remaining returns the number of steps left after the current one
+func (s rebaseState) Remaining() int {
+	if s.Total < s.Current {
+		return 0
+	}
+	return s.Total - s.Current
+}
+
+// NewGitRebaseStatusCmd reports on, and steps through, an in-progress rebase
+func NewGitRebaseStatusCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "rebase-status",
+		Usage: "Show rebase progress and conflicted files, or continue/skip/abort the rebase",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "continue",
+				Usage: "Continue the rebase after resolving conflicts",
+			},
+			&ufcli.BoolFlag{
+				Name:  "skip",
+				Usage: "Skip the current commit",
+			},
+			&ufcli.BoolFlag{
+				Name:  "abort",
+				Usage: "Abort the rebase and restore the original branch",
+			},
+			&ufcli.BoolFlag{
+				Name:  "push",
+				Usage: "When --continue or --skip finishes the rebase, force push (with lease) without asking",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			action := ""
+			for _, name := range []string{"continue", "skip", "abort"} {
+				if !c.Bool(name) {
+					continue
+				}
+				if action != "" {
+					return fmt.Errorf("--%s and --%s cannot be used together", action, name)
+				}
+				action = name
+			}
+
+			state, err := getRebaseState(ctx)
+			if err != nil {
+				return err
+			}
+			if !state.InProgress {
+				fmt.Println("No rebase in progress")
+				return nil
+			}
+
+			if action == "" {
+				return printRebaseStatus(ctx, state)
+			}
+
+			// GIT_EDITOR=true keeps the existing commit message on --continue
+			err = shell.RunInteractiveWithEnv(ctx, []string{"GIT_EDITOR=true"}, "git", "rebase", "--"+action)
+			// Finishing or aborting the rebase moves HEAD back onto a branch
+			repo.InvalidateCurrentBranch(ctx)
+			if err != nil {
+				if action == "abort" {
+					return fmt.Errorf("failed to abort rebase: %w", err)
+				}
+				fmt.Println()
+				if state, stateErr := getRebaseState(ctx); stateErr == nil && state.InProgress {
+					_ = printRebaseStatus(ctx, state)
+				}
+				return fmt.Errorf("rebase stopped: %w", err)
+			}
+
+			if action == "abort" {
+				fmt.Println("✓ Rebase aborted")
+				return nil
+			}
+
+			state, err = getRebaseState(ctx)
+			if err != nil {
+				return err
+			}
+			if state.InProgress {
+				return printRebaseStatus(ctx, state)
+			}
+
+			fmt.Println("✓ Rebase complete")
+			return offerForcePush(ctx, c.Bool("push"))
+		},
+	}
+}
+
+// printRebaseStatus prints rebase progress and any conflicted files
+func printRebaseStatus(ctx context.Context, state rebaseState) error {
+	fmt.Println("Rebase in progress")
+	if state.Total > 0 {
+		fmt.Printf("Step %d of %d (%d remaining)\n", state.Current, state.Total, state.Remaining())
+	}
+
+	conflicts, err := getConflictedFiles(ctx)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		fmt.Println("\nNo conflicted files. Run 'cc git rebase-status --continue' to proceed.")
+		return nil
+	}
+
+	fmt.Println("\nConflicted files:")
+	for _, file := range conflicts {
+		fmt.Printf("  %s\n", file)
+	}
+	fmt.Println("\nResolve the conflicts, 'git add' the files, then run 'cc git rebase-status --continue'.")
+	return nil
+}
+
+// getRebaseState inspects .git/rebase-merge or .git/rebase-apply for progress
+func getRebaseState(ctx context.Context) (rebaseState, error) {
+	// rebase-merge is used by interactive and merge-backend rebases,
+	// rebase-apply by the older apply backend
+	stateFiles := []struct {
+		dir, current, total string
+	}{
+		{"rebase-merge", "msgnum", "end"},
+		{"rebase-apply", "next", "last"},
+	}
+
+	for _, sf := range stateFiles {
+		dir, err := shell.Run(ctx, "git", "rev-parse", "--path-format=absolute", "--git-path", sf.dir)
+		if err != nil {
+			return rebaseState{}, fmt.Errorf("failed to locate rebase state: %w", err)
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+
+		return rebaseState{
+			InProgress: true,
+			Current:    readStepFile(dir + "/" + sf.current),
+			Total:      readStepFile(dir + "/" + sf.total),
+		}, nil
+	}
+
+	return rebaseState{}, nil
+}
+
+// readStepFile reads a step counter from a rebase state file, returning 0 if unavailable
+func readStepFile(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// getConflictedFiles lists files with unresolved merge conflicts
+func getConflictedFiles(ctx context.Context) ([]string, error) {
+	output, err := shell.Run(ctx, "git", "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicted files: %w", err)
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// offerForcePush asks whether to push the rebased branch with
+// --force-with-lease, or pushes without asking when push is set. The rebase
+// has already succeeded, so protected branches and a missing terminal skip
+// the push with a hint rather than failing.
+func offerForcePush(ctx context.Context, push bool) error {
+	currentBranch, err := getCurrentBranch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	if protected, err := isProtectedBranch(ctx, currentBranch); err != nil {
+		return err
+	} else if protected {
+		fmt.Printf("⚠ '%s' is protected; not force pushing it\n", currentBranch)
+		return nil
+	}
+
+	if !push {
+		confirmed, err := prompt.Confirm(fmt.Sprintf("Force push '%s' to origin (with lease)?", currentBranch))
+		if errors.Is(err, prompt.ErrNotInteractive) {
+			fmt.Printf("Not pushing: stdin is not a terminal. Run 'git push --force-with-lease origin %s', or pass --push next time\n", currentBranch)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading input: %w", err)
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	if _, err := shell.Run(ctx, "git", "push", "--force-with-lease", "origin", currentBranch); err != nil {
+		return fmt.Errorf("failed to force push: %w", err)
+	}
+	fmt.Printf("✓ Pushed '%s' to origin\n", currentBranch)
+	return nil
+}
@@ -0,0 +1,221 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/prompt"
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// semverTagPattern matches tags such as v1.2.3 or 1.2.3-rc.1
+var semverTagPattern = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?$`)
+
+// prereleaseLabelPattern restricts --prerelease to a valid semver identifier
+var prereleaseLabelPattern = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+
+// semver is a parsed release version
+type semver struct {
+	Prefix     string // "v" or ""
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// String formats the version as a tag name
+func (v semver) String() string {
+	tag := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		tag += "-" + v.Prerelease
+	}
+	return tag
+}
+
+// parseSemverTag parses a tag name, reporting whether it is a semver tag
+func parseSemverTag(tag string) (semver, bool) {
+	match := semverTagPattern.FindStringSubmatch(tag)
+	if match == nil {
+		return semver{}, false
+	}
+	major, _ := strconv.Atoi(match[2])
+	minor, _ := strconv.Atoi(match[3])
+	patch, _ := strconv.Atoi(match[4])
+	return semver{Prefix: match[1], Major: major, Minor: minor, Patch: patch, Prerelease: match[5]}, true
+}
+
+// NewGitReleaseCmd tags the next semantic version
+func NewGitReleaseCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "release",
+		Usage:     "Create an annotated tag for the next major, minor, or patch version",
+		ArgsUsage: "<major|minor|patch>",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "prerelease",
+				Usage: "Tag a prerelease with this label, e.g. rc produces v1.3.0-rc.1",
+			},
+			&ufcli.BoolFlag{
+				Name:  "push",
+				Usage: "Push the new tag to origin",
+			},
+			&ufcli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Create the tag without asking for confirmation",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+			if c.NArg() < 1 {
+				return fmt.Errorf("version component is required: major, minor, or patch")
+			}
+			component := c.Args().First()
+			if _, err := bumpVersion(semver{}, component); err != nil {
+				return err
+			}
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			label := c.String("prerelease")
+			if label != "" && !prereleaseLabelPattern.MatchString(label) {
+				return fmt.Errorf("prerelease label %q may only contain letters, digits, and dashes", label)
+			}
+
+			tags, err := getSemverTags(ctx)
+			if err != nil {
+				return err
+			}
+
+			latest, found := latestRelease(tags)
+			if found {
+				fmt.Printf("Latest release: %s\n", latest)
+			} else {
+				fmt.Println("No release tags found, starting from v0.0.0")
+				latest = semver{Prefix: "v"}
+			}
+
+			next, err := bumpVersion(latest, component)
+			if err != nil {
+				return err
+			}
+			if label != "" {
+				next.Prerelease = fmt.Sprintf("%s.%d", label, nextPrereleaseNumber(tags, next, label))
+			}
+
+			tag := next.String()
+			fmt.Printf("Next release:   %s\n", tag)
+
+			if !c.Bool("yes") {
+				confirmed, err := prompt.Confirm(fmt.Sprintf("Create tag %s?", tag))
+				if errors.Is(err, prompt.ErrNotInteractive) {
+					return fmt.Errorf("%w; pass --yes to tag without confirmation", err)
+				}
+				if err != nil {
+					return fmt.Errorf("error reading input: %w", err)
+				}
+				if !confirmed {
+					fmt.Println("Release cancelled")
+					return nil
+				}
+			}
+
+			if output, err := shell.Run(ctx, "git", "tag", "-a", tag, "-m", "Release "+tag); err != nil {
+				return fmt.Errorf("failed to create tag %s: %w\n%s", tag, err, output)
+			}
+			fmt.Printf("✓ Created tag %s\n", tag)
+
+			if c.Bool("push") {
+				if output, err := shell.Run(ctx, "git", "push", "origin", tag); err != nil {
+					return fmt.Errorf("failed to push tag %s: %w\n%s", tag, err, output)
+				}
+				fmt.Printf("✓ Pushed %s to origin\n", tag)
+			}
+			return nil
+		},
+	}
+}
+
+// getSemverTags returns the repository's semver tags, highest version first
+func getSemverTags(ctx context.Context) ([]semver, error) {
+	output, err := shell.Run(ctx, "git", "tag", "--list", "--sort=-v:refname")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	var tags []semver
+	for _, line := range strings.Split(output, "\n") {
+		if v, ok := parseSemverTag(strings.TrimSpace(line)); ok {
+			tags = append(tags, v)
+		}
+	}
+	return tags, nil
+}
+
+// latestRelease returns the highest tag that is not a prerelease
+func latestRelease(tags []semver) (semver, bool) {
+	var latest semver
+	found := false
+	for _, v := range tags {
+		if v.Prerelease != "" {
+			continue
+		}
+		if !found || compareCore(v, latest) > 0 {
+			latest, found = v, true
+		}
+	}
+	return latest, found
+}
+
+// compareCore compares the major.minor.patch of two versions
+func compareCore(a, b semver) int {
+	switch {
+	case a.Major != b.Major:
+		return a.Major - b.Major
+	case a.Minor != b.Minor:
+		return a.Minor - b.Minor
+	default:
+		return a.Patch - b.Patch
+	}
+}
+
+// bumpVersion increments the named component and resets the lower ones
+func bumpVersion(v semver, component string) (semver, error) {
+	next := semver{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch component {
+	case "major":
+		next.Major++
+		next.Minor, next.Patch = 0, 0
+	case "minor":
+		next.Minor++
+		next.Patch = 0
+	case "patch":
+		next.Patch++
+	default:
+		return semver{}, fmt.Errorf("version component must be 'major', 'minor', or 'patch', got %s", component)
+	}
+	return next, nil
+}
+
+// nextPrereleaseNumber returns one more than the highest existing
+// <label>.N prerelease of next, starting at 1
+func nextPrereleaseNumber(tags []semver, next semver, label string) int {
+	highest := 0
+	for _, v := range tags {
+		if compareCore(v, next) != 0 || !strings.HasPrefix(v.Prerelease, label+".") {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimPrefix(v.Prerelease, label+".")); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return highest + 1
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/christopher.carver/cc/internal/repo"
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// NewGitSyncCmd rebases the current branch onto the latest default branch
+func NewGitSyncCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "sync",
+		Usage: "Fetch origin and rebase the current branch onto the latest default branch",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "autostash",
+				Usage: "Stash uncommitted changes before the rebase and restore them afterwards",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			currentBranch, err := getCurrentBranch(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get current branch: %w", err)
+			}
+			defaultBranch, err := getDefaultBranch(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to determine default branch: %w", err)
+			}
+
+			hasChanges, err := hasUncommittedChanges(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to check for uncommitted changes: %w", err)
+			}
+			if hasChanges && !c.Bool("autostash") {
+				return fmt.Errorf("uncommitted changes detected. Commit or stash them, or use --autostash")
+			}
+
+			fmt.Printf("Fetching origin %s...\n", defaultBranch)
+			if output, err := shell.Run(ctx, "git", "fetch", "origin", defaultBranch); err != nil {
+				return fmt.Errorf("failed to fetch origin %s: %w\n%s", defaultBranch, err, output)
+			}
+			base := "origin/" + defaultBranch
+
+			ahead, behind, err := getBranchStatus(ctx, currentBranch, base)
+			if err != nil {
+				return fmt.Errorf("failed to compare with %s: %w", base, err)
+			}
+			fmt.Printf("Before: %d ahead, %d behind %s\n", ahead, behind, base)
+			if behind == 0 {
+				fmt.Printf("✓ '%s' is already up to date with %s\n", currentBranch, base)
+				return nil
+			}
+
+			// git restores autostashed changes when the rebase finishes. If it
+			// stops, the stash is kept until --continue or --abort.
+			args := []string{"rebase", base}
+			if hasChanges {
+				args = []string{"rebase", "--autostash", base}
+			}
+			fmt.Printf("Rebasing '%s' onto %s...\n", currentBranch, base)
+			err = shell.RunInteractive(ctx, "git", args...)
+			// A stopped rebase leaves HEAD detached
+			repo.InvalidateCurrentBranch(ctx)
+			if err != nil {
+				if hasChanges {
+					return fmt.Errorf("rebase stopped: %w. Resolve the conflicts and run 'cc git rebase-status --continue'. Your local changes are still stashed and come back after --continue or --abort", err)
+				}
+				return fmt.Errorf("rebase stopped: %w. Resolve the conflicts and run 'cc git rebase-status --continue'", err)
+			}
+
+			ahead, behind, err = getBranchStatus(ctx, currentBranch, base)
+			if err != nil {
+				return fmt.Errorf("failed to compare with %s: %w", base, err)
+			}
+			fmt.Printf("After: %d ahead, %d behind %s\n", ahead, behind, base)
+			fmt.Printf("✓ Synced '%s' with %s\n", currentBranch, base)
+			return nil
+		},
+	}
+}
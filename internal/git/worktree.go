@@ -0,0 +1,162 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// NewGitWorktreeCmd creates the worktree command group
+func NewGitWorktreeCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "worktree",
+		Usage: "Manage git worktrees for working on several branches at once",
+		Subcommands: []*ufcli.Command{
+			NewGitWorktreeListCmd(),
+			NewGitWorktreeAddCmd(),
+			NewGitWorktreeRemoveCmd(),
+		},
+	}
+}
+
+// NewGitWorktreeListCmd lists the repository's worktrees
+func NewGitWorktreeListCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "list",
+		Usage: "List worktrees",
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			output, err := shell.Run(ctx, "git", "worktree", "list")
+			if err != nil {
+				return fmt.Errorf("failed to list worktrees: %w", err)
+			}
+			fmt.Println(output)
+			return nil
+		},
+	}
+}
+
+// NewGitWorktreeAddCmd adds a worktree, creating the branch from the
+// default branch if it doesn't exist yet
+func NewGitWorktreeAddCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "add",
+		Usage:     "Add a worktree for a branch (created from main/master if missing)",
+		ArgsUsage: "<path> <branch>",
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 2 {
+				return fmt.Errorf("path and branch are required")
+			}
+			path := c.Args().Get(0)
+			branch := c.Args().Get(1)
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			// Relative paths are relative to -C, like git's own
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(shell.Dir(ctx), path)
+			}
+			absPath, err := filepath.Abs(path)
+			if err != nil {
+				return fmt.Errorf("invalid path: %w", err)
+			}
+			if _, err := os.Stat(absPath); err == nil {
+				return fmt.Errorf("path already exists: %s", absPath)
+			}
+
+			// Use the existing branch, or create it from the default branch
+			if _, err := shell.Run(ctx, "git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+				fmt.Printf("Adding worktree at '%s' for branch '%s'...\n", absPath, branch)
+				if output, err := shell.Run(ctx, "git", "worktree", "add", absPath, branch); err != nil {
+					return fmt.Errorf("failed to add worktree: %w\n%s", err, output)
+				}
+			} else {
+				defaultBranch, err := getDefaultBranch(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to determine default branch: %w", err)
+				}
+				fmt.Printf("Adding worktree at '%s' with new branch '%s' from '%s'...\n", absPath, branch, defaultBranch)
+				if output, err := shell.Run(ctx, "git", "worktree", "add", "-b", branch, absPath, defaultBranch); err != nil {
+					return fmt.Errorf("failed to add worktree: %w\n%s", err, output)
+				}
+			}
+
+			fmt.Printf("✓ Worktree ready at %s\n", absPath)
+			return nil
+		},
+	}
+}
+
+// NewGitWorktreeRemoveCmd removes a worktree, refusing when it has
+// uncommitted changes unless forced
+func NewGitWorktreeRemoveCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "remove",
+		Usage:     "Remove a worktree",
+		ArgsUsage: "<path>",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Remove even if the worktree has uncommitted changes",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 1 {
+				return fmt.Errorf("worktree path is required")
+			}
+			ctx := c.Context
+
+			// Check if we're in a git repo
+			if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+				return fmt.Errorf("not in a git repository")
+			}
+
+			path := c.Args().First()
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(shell.Dir(ctx), path)
+			}
+			absPath, err := filepath.Abs(path)
+			if err != nil {
+				return fmt.Errorf("invalid path: %w", err)
+			}
+			if info, err := os.Stat(absPath); err != nil || !info.IsDir() {
+				return fmt.Errorf("worktree does not exist: %s", absPath)
+			}
+
+			args := []string{"worktree", "remove"}
+			if c.Bool("force") {
+				args = append(args, "--force")
+			} else {
+				status, err := shell.RunWithDir(ctx, absPath, "git", "status", "--porcelain")
+				if err != nil {
+					return fmt.Errorf("failed to check worktree status: %w", err)
+				}
+				if status != "" {
+					return fmt.Errorf("worktree has uncommitted changes. Commit or stash them, or use --force")
+				}
+			}
+
+			fmt.Printf("Removing worktree '%s'...\n", absPath)
+			if output, err := shell.Run(ctx, "git", append(args, absPath)...); err != nil {
+				return fmt.Errorf("failed to remove worktree: %w\n%s", err, output)
+			}
+
+			fmt.Printf("✓ Removed worktree %s\n", absPath)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+)
+
+// ErrNotInteractive is returned when a prompt is needed but stdin is not a
+// terminal. Callers should tell the user which flag skips the prompt.
+var ErrNotInteractive = errors.New("confirmation required but stdin is not a terminal")
+
+// Confirm asks a yes/no question and reports whether the user answered yes
+func Confirm(message string) (bool, error) {
+	response, err := ask(message + " (y/n): ")
+	if err != nil {
+		return false, err
+	}
+
+	response = strings.ToLower(response)
+	return response == "y" || response == "yes", nil
+}
+
+// ConfirmExact asks the user to type expected exactly, for destructive
+// operations where a stray "y" is too easy
+func ConfirmExact(message, expected string) (bool, error) {
+	response, err := ask(fmt.Sprintf("%s Type '%s' to confirm: ", message, expected))
+	if err != nil {
+		return false, err
+	}
+	return response == expected, nil
+}
+
+// ask prints the question and returns the trimmed answer
+func ask(question string) (string, error) {
+	if !shell.StdinIsTTY() {
+		return "", ErrNotInteractive
+	}
+
+	fmt.Print(question)
+	response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(response), nil
+}
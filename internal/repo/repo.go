@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/christopher.carver/cc/internal/shell"
+)
+
+// branchCache holds the branch lookups for one repository directory
+type branchCache struct {
+	defaultBranch string
+	currentBranch string
+}
+
+var (
+	cacheMu sync.Mutex
+	caches  = map[string]*branchCache{}
+)
+
+// cacheFor returns the cache for the directory ctx's commands run in.
+// cacheMu must be held.
+func cacheFor(ctx context.Context) *branchCache {
+	dir := shell.Dir(ctx)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	cache, ok := caches[dir]
+	if !ok {
+		cache = &branchCache{}
+		caches[dir] = cache
+	}
+	return cache
+}
+
+// DefaultBranch returns the repository's default branch. It prefers the
+// remote's origin/HEAD, then falls back to a local main, then master.
+// The result is cached per repository for the lifetime of the process so
+// every command agrees on the same answer without re-running git.
+func DefaultBranch(ctx context.Context) (string, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	cache := cacheFor(ctx)
+	if cache.defaultBranch != "" {
+		return cache.defaultBranch, nil
+	}
+
+	branch, err := lookupDefaultBranch(ctx)
+	if err != nil {
+		return "", err
+	}
+	cache.defaultBranch = branch
+	return branch, nil
+}
+
+// CurrentBranch returns the checked-out branch, or "HEAD" when detached.
+// The result is cached per repository until InvalidateCurrentBranch is
+// called after an operation that can move HEAD, such as checkout or rebase.
+func CurrentBranch(ctx context.Context) (string, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	cache := cacheFor(ctx)
+	if cache.currentBranch != "" {
+		return cache.currentBranch, nil
+	}
+
+	branch, err := shell.Run(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	cache.currentBranch = branch
+	return branch, nil
+}
+
+// InvalidateCurrentBranch forgets the cached current branch of ctx's
+// repository so the next CurrentBranch call asks git again
+func InvalidateCurrentBranch(ctx context.Context) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	cacheFor(ctx).currentBranch = ""
+}
+
+// lookupDefaultBranch asks git for the default branch without caching
+func lookupDefaultBranch(ctx context.Context) (string, error) {
+	// Try to get the default branch from remote
+	output, err := shell.Run(ctx, "git", "symbolic-ref", "refs/remotes/origin/HEAD")
+	if err == nil {
+		// Parse output like "refs/remotes/origin/main"
+		if branch := strings.TrimPrefix(output, "refs/remotes/origin/"); branch != "" && branch != output {
+			return branch, nil
+		}
+	}
+
+	// Fallback: check if main exists, otherwise use master
+	if _, err := shell.Run(ctx, "git", "show-ref", "--verify", "--quiet", "refs/heads/main"); err == nil {
+		return "main", nil
+	}
+	return "master", nil
+}
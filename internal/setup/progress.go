@@ -0,0 +1,106 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/christopher.carver/cc/internal/shell"
+)
+
+// progressTracker serializes per-package status output so concurrent checks
+// don't interleave. Packages are tracked by index, so two with the same
+// display name keep separate lines. On a terminal each package gets one
+// status line that is redrawn in place. With --dry-run or --verbose, which
+// print their own lines in between, each update is printed as a plain line
+// instead; otherwise updates are buffered and flushed in order.
+type progressTracker struct {
+	mu       sync.Mutex
+	names    []string
+	steps    []string
+	warnings [][]string
+	tty      bool
+	plain    bool
+	drawn    bool
+}
+
+// newProgressTracker creates a tracker for the given packages, in display order
+func newProgressTracker(ctx context.Context, names []string) *progressTracker {
+	plain := shell.IsDryRun(ctx) || shell.IsVerbose(ctx)
+	p := &progressTracker{
+		names:    names,
+		steps:    make([]string, len(names)),
+		warnings: make([][]string, len(names)),
+		tty:      shell.StdoutIsTTY() && !plain,
+		plain:    plain,
+	}
+	for i := range names {
+		p.steps[i] = "pending"
+	}
+	return p
+}
+
+// Update sets the current step of the package at index i, e.g. "checking"
+// or "upgrade available"
+func (p *progressTracker) Update(i int, step string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.steps[i] = step
+	switch {
+	case p.tty:
+		p.redraw()
+	case p.plain:
+		fmt.Println(p.line(i))
+	}
+}
+
+// Warn records a warning for the package at index i, shown once tracking finishes
+func (p *progressTracker) Warn(i int, format string, args ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.warnings[i] = append(p.warnings[i], fmt.Sprintf(format, args...))
+}
+
+// Finish prints the final status of every package in order, followed by
+// any warnings collected along the way
+func (p *progressTracker) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case p.tty:
+		p.redraw()
+	case !p.plain:
+		for i := range p.names {
+			fmt.Println(p.line(i))
+		}
+	}
+
+	for i, name := range p.names {
+		for _, warning := range p.warnings[i] {
+			fmt.Printf("⚠ Warning: %s: %s\n", name, warning)
+		}
+	}
+}
+
+// redraw rewrites every status line in place; callers must hold p.mu
+func (p *progressTracker) redraw() {
+	var b strings.Builder
+	if p.drawn {
+		fmt.Fprintf(&b, "\033[%dA", len(p.names))
+	}
+	for i := range p.names {
+		b.WriteString("\033[2K" + p.line(i) + "\n")
+	}
+	fmt.Fprint(os.Stdout, b.String())
+	p.drawn = true
+}
+
+// line formats the status line of the package at index i
+func (p *progressTracker) line(i int) string {
+	return fmt.Sprintf("  %-20s %s", p.names[i], p.steps[i])
+}
@@ -1,14 +1,20 @@
 package setup
 
 import (
-	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/christopher.carver/cc/internal/config"
+	"github.com/christopher.carver/cc/internal/prompt"
 	"github.com/christopher.carver/cc/internal/shell"
 	ufcli "github.com/urfave/cli/v2"
 )
@@ -32,17 +38,76 @@ type PackageInfo struct {
 	LatestVersion  string
 	NeedsUpgrade   bool
 	NeedsInstall   bool
+	Pinned         bool
 }
 
-// RequiredPackages is the list of packages that should be checked
-var RequiredPackages = []struct {
-	Name        string
-	DisplayName string
-}{
-	{"cursor", "Cursor"},
-	{"go", "GoLang"},
-	{"sequel-ace", "SequelAce"},
-	{"utm", "UTM"},
+// RequiredPackages is the list of packages that should be checked.
+// Additional packages, including ones from private taps, can be listed
+// under `packages:` in ~/.cc/config.yaml.
+var RequiredPackages = []config.Package{
+	{Name: "cursor", DisplayName: "Cursor"},
+	{Name: "go", DisplayName: "GoLang"},
+	{Name: "sequel-ace", DisplayName: "SequelAce"},
+	{Name: "utm", DisplayName: "UTM"},
+}
+
+// loadPackages returns the built-in packages followed by those from the
+// config file. A config entry with the same name as a built-in replaces it.
+func loadPackages() ([]config.Package, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	packages := append([]config.Package{}, RequiredPackages...)
+	for _, pkg := range cfg.Packages {
+		if pkg.Name == "" {
+			return nil, fmt.Errorf("package entry in %s is missing a name", cfg.Path)
+		}
+		if pkg.DisplayName == "" {
+			pkg.DisplayName = pkg.Name
+		}
+
+		replaced := false
+		for i := range packages {
+			if packages[i].Name == pkg.Name {
+				packages[i] = pkg
+				replaced = true
+			}
+		}
+		if !replaced {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages, nil
+}
+
+// getTaps returns the set of Homebrew taps that are already added
+func getTaps(ctx context.Context) (map[string]bool, error) {
+	output, err := shell.Run(ctx, "brew", "tap")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list taps: %w", err)
+	}
+
+	taps := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		if tap := strings.TrimSpace(line); tap != "" {
+			taps[strings.ToLower(tap)] = true
+		}
+	}
+	return taps, nil
+}
+
+// ensureTap adds a tap unless it is already present in taps
+func ensureTap(ctx context.Context, tap string, taps map[string]bool) error {
+	if taps[strings.ToLower(tap)] {
+		return nil
+	}
+	if output, err := shell.Run(ctx, "brew", "tap", tap); err != nil {
+		return fmt.Errorf("failed to tap %s: %w\n%s", tap, err, output)
+	}
+	taps[strings.ToLower(tap)] = true
+	return nil
 }
 
 // checkHomebrewInstalled checks if Homebrew is installed
@@ -54,17 +119,68 @@ func checkHomebrewInstalled(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
-// installHomebrew installs Homebrew if it's not already installed
-func installHomebrew(ctx context.Context) error {
-	fmt.Println("Homebrew is not installed. Installing Homebrew...")
-	fmt.Println("This may take a few minutes...")
+// homebrewInstallURL is the official Homebrew installation script
+const homebrewInstallURL = "https://raw.githubusercontent.com/Homebrew/install/HEAD/install.sh"
+
+// installHomebrew installs Homebrew if it's not already installed.
+// The install script is downloaded to a temp file and, when a checksum is
+// configured, verified before it runs. Unless trust is set the user is shown
+// the script's size and location and asked to confirm before it executes.
+func installHomebrew(ctx context.Context, trust bool) error {
+	fmt.Println("Homebrew is not installed. Downloading the Homebrew install script...")
+
+	tmpFile, err := os.CreateTemp("", "homebrew-install-*.sh")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	scriptPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(scriptPath)
+
+	if _, err := shell.Run(ctx, "curl", "-fsSL", "-o", scriptPath, homebrewInstallURL); err != nil {
+		return fmt.Errorf("failed to download Homebrew install script: %w", err)
+	}
+
+	data, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return fmt.Errorf("failed to read Homebrew install script: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	fmt.Printf("  Script:   %s\n", scriptPath)
+	fmt.Printf("  Size:     %d bytes\n", len(data))
+	fmt.Printf("  SHA-256:  %s\n", checksum)
+
+	// Verify against a pinned checksum when one is configured
+	if expected := config.String("homebrew_install_sha256"); expected != "" {
+		if !strings.EqualFold(expected, checksum) {
+			return fmt.Errorf("Homebrew install script checksum mismatch: expected %s, got %s", expected, checksum)
+		}
+		fmt.Println("  ✓ Checksum matches pinned value")
+	} else {
+		fmt.Println("  ⚠ No pinned checksum configured (set homebrew_install_sha256 to verify)")
+	}
+	fmt.Println()
+
+	if !trust {
+		confirm, err := prompt.Confirm("Run the downloaded install script?")
+		if errors.Is(err, prompt.ErrNotInteractive) {
+			return fmt.Errorf("%w; pass --trust to run it without confirmation", err)
+		}
+		if err != nil {
+			return fmt.Errorf("error reading input: %w", err)
+		}
+		if !confirm {
+			return fmt.Errorf("Homebrew installation cancelled")
+		}
+	}
+
+	fmt.Println("Installing Homebrew. This may take a few minutes...")
 	fmt.Println()
 
-	// Use the official Homebrew installation script
 	// Run the installation script interactively so user can see progress
-	// The script URL is passed as an argument to curl
-	err := shell.RunInteractive(ctx, "/bin/bash", "-c", "curl -fsSL https://raw.githubusercontent.com/Homebrew/install/HEAD/install.sh | bash")
-	if err != nil {
+	if err := shell.RunInteractive(ctx, "/bin/bash", scriptPath); err != nil {
 		return fmt.Errorf("failed to install Homebrew: %w", err)
 	}
 
@@ -191,6 +307,20 @@ func compareVersions(current, latest string) bool {
 	return current != latest
 }
 
+// getPinnedPackages returns the set of formulae pinned via `brew pin`
+func getPinnedPackages(ctx context.Context) (map[string]bool, error) {
+	output, err := shell.Run(ctx, "brew", "list", "--pinned")
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := make(map[string]bool)
+	for _, name := range strings.Fields(output) {
+		pinned[name] = true
+	}
+	return pinned, nil
+}
+
 // upgradePackage upgrades a package with progress indication
 func upgradePackage(ctx context.Context, packageName string, pkgType PackageType) error {
 	var args []string
@@ -213,6 +343,20 @@ func upgradePackage(ctx context.Context, packageName string, pkgType PackageType
 	return nil
 }
 
+// upgradePackages upgrades each package in turn and returns the ones that failed
+func upgradePackages(ctx context.Context, packages []PackageInfo) []PackageInfo {
+	var failed []PackageInfo
+	for _, pkg := range packages {
+		if err := upgradePackage(ctx, pkg.Name, pkg.Type); err != nil {
+			fmt.Printf("✗ Error upgrading %s: %v\n", pkg.DisplayName, err)
+			failed = append(failed, pkg)
+		}
+		// Small delay to make progress visible
+		time.Sleep(500 * time.Millisecond)
+	}
+	return failed
+}
+
 // printSummaryTable prints a formatted table of package statuses
 func printSummaryTable(packages []PackageInfo) {
 	fmt.Println("\n" + strings.Repeat("=", 80))
@@ -224,7 +368,10 @@ func printSummaryTable(packages []PackageInfo) {
 		action := "Install"
 
 		if pkg.Installed {
-			if pkg.NeedsUpgrade {
+			if pkg.Pinned {
+				status = "Pinned"
+				action = "None"
+			} else if pkg.NeedsUpgrade {
 				status = "Update Available"
 				action = "Upgrade"
 			} else {
@@ -249,18 +396,339 @@ func printSummaryTable(packages []PackageInfo) {
 	fmt.Println(strings.Repeat("=", 80) + "\n")
 }
 
-// promptConfirmation asks the user for confirmation
-func promptConfirmation(message string) (bool, error) {
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Print(message + " (y/n): ")
+// NewSetupPinCmd creates the pin command
+func NewSetupPinCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "pin",
+		Usage:     "Pin a Homebrew formula so setup will not upgrade it",
+		ArgsUsage: "<name>",
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 1 {
+				return fmt.Errorf("package name is required")
+			}
+			name := c.Args().First()
 
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return false, err
+			if _, err := shell.Run(c.Context, "brew", "pin", name); err != nil {
+				return fmt.Errorf("failed to pin %s: %w", name, err)
+			}
+			fmt.Printf("✓ Pinned %s\n", name)
+			return nil
+		},
+	}
+}
+
+// NewSetupUnpinCmd creates the unpin command
+func NewSetupUnpinCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "unpin",
+		Usage:     "Unpin a Homebrew formula so setup can upgrade it again",
+		ArgsUsage: "<name>",
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 1 {
+				return fmt.Errorf("package name is required")
+			}
+			name := c.Args().First()
+
+			if _, err := shell.Run(c.Context, "brew", "unpin", name); err != nil {
+				return fmt.Errorf("failed to unpin %s: %w", name, err)
+			}
+			fmt.Printf("✓ Unpinned %s\n", name)
+			return nil
+		},
+	}
+}
+
+// NewSetupReinstallCmd creates the reinstall command
+func NewSetupReinstallCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "reinstall",
+		Usage:     "Reinstall a broken Homebrew package, relinking it if needed",
+		ArgsUsage: "<name>",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Reinstall, and relink on a conflict, without asking for confirmation",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 1 {
+				return fmt.Errorf("package name is required")
+			}
+			name := c.Args().First()
+			ctx := c.Context
+
+			pkgType, err := detectPackageType(ctx, name)
+			if err != nil {
+				return err
+			}
+
+			if !c.Bool("yes") {
+				confirm, err := prompt.Confirm(fmt.Sprintf("Reinstall %s (%s)?", name, pkgType))
+				if errors.Is(err, prompt.ErrNotInteractive) {
+					return fmt.Errorf("%w; pass --yes to reinstall without confirmation", err)
+				}
+				if err != nil {
+					return fmt.Errorf("error reading input: %w", err)
+				}
+				if !confirm {
+					fmt.Println("Reinstall cancelled")
+					return nil
+				}
+			}
+
+			args := []string{"reinstall", name}
+			if pkgType == PackageTypeCask {
+				args = []string{"reinstall", "--cask", name}
+			}
+
+			fmt.Printf("Reinstalling %s...\n", name)
+			output, err := shell.Run(ctx, "brew", args...)
+			fmt.Println(output)
+
+			// Formula link conflicts leave the package installed but not on the PATH
+			if pkgType == PackageTypeFormula && hasLinkConflict(output) {
+				relink := c.Bool("yes")
+				if !relink {
+					var promptErr error
+					relink, promptErr = prompt.Confirm(fmt.Sprintf("A link conflict was detected. Run 'brew link --overwrite %s'?", name))
+					if errors.Is(promptErr, prompt.ErrNotInteractive) {
+						return fmt.Errorf("%w; pass --yes to relink without confirmation", promptErr)
+					}
+					if promptErr != nil {
+						return fmt.Errorf("error reading input: %w", promptErr)
+					}
+				}
+				if relink {
+					if linkOutput, linkErr := shell.Run(ctx, "brew", "link", "--overwrite", name); linkErr != nil {
+						return fmt.Errorf("failed to link %s: %w\n%s", name, linkErr, linkOutput)
+					}
+					fmt.Printf("✓ Linked %s\n", name)
+					return nil
+				}
+			}
+
+			if err != nil {
+				return fmt.Errorf("failed to reinstall %s: %w", name, err)
+			}
+			fmt.Printf("✓ Reinstalled %s\n", name)
+			return nil
+		},
+	}
+}
+
+// brewInfo is the subset of `brew info --json=v2` output shown by setup info
+type brewInfo struct {
+	Formulae []struct {
+		Name     string `json:"name"`
+		Desc     string `json:"desc"`
+		Homepage string `json:"homepage"`
+		Versions struct {
+			Stable string `json:"stable"`
+		} `json:"versions"`
+		Installed []struct {
+			Version string `json:"version"`
+		} `json:"installed"`
+	} `json:"formulae"`
+	Casks []struct {
+		Token     string  `json:"token"`
+		Desc      string  `json:"desc"`
+		Homepage  string  `json:"homepage"`
+		Version   string  `json:"version"`
+		Installed *string `json:"installed"`
+	} `json:"casks"`
+}
+
+// NewSetupInfoCmd creates the info command
+func NewSetupInfoCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "info",
+		Usage:     "Summarize what a Homebrew package is and the binaries or apps it provides",
+		ArgsUsage: "<name>",
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 1 {
+				return fmt.Errorf("package name is required")
+			}
+			name := c.Args().First()
+			ctx := c.Context
+
+			pkgType, err := detectPackageType(ctx, name)
+			if err != nil {
+				return err
+			}
+
+			typeFlag := "--formula"
+			if pkgType == PackageTypeCask {
+				typeFlag = "--cask"
+			}
+
+			output, err := shell.Run(ctx, "brew", "info", "--json=v2", typeFlag, name)
+			if err != nil {
+				return fmt.Errorf("brew info failed: %w", err)
+			}
+			var info brewInfo
+			if err := json.Unmarshal([]byte(output), &info); err != nil {
+				return fmt.Errorf("failed to parse brew info: %w", err)
+			}
+
+			var desc, homepage, latest, installed string
+			switch {
+			case len(info.Formulae) > 0:
+				f := info.Formulae[0]
+				desc, homepage, latest = f.Desc, f.Homepage, f.Versions.Stable
+				if len(f.Installed) > 0 {
+					installed = f.Installed[len(f.Installed)-1].Version
+				}
+			case len(info.Casks) > 0:
+				ck := info.Casks[0]
+				desc, homepage, latest = ck.Desc, ck.Homepage, ck.Version
+				if ck.Installed != nil {
+					installed = *ck.Installed
+				}
+			default:
+				return fmt.Errorf("brew info returned no details for %s", name)
+			}
+
+			if installed == "" {
+				installed = "not installed"
+			}
+
+			fmt.Printf("%s (%s)\n", name, pkgType)
+			fmt.Printf("  Description: %s\n", desc)
+			fmt.Printf("  Homepage:    %s\n", homepage)
+			fmt.Printf("  Latest:      %s\n", latest)
+			fmt.Printf("  Installed:   %s\n", installed)
+
+			if installed == "not installed" {
+				return nil
+			}
+
+			files, err := shell.Run(ctx, "brew", "list", typeFlag, name)
+			if err != nil {
+				return fmt.Errorf("brew list failed: %w", err)
+			}
+			provided := providedFiles(files, pkgType)
+			if len(provided) == 0 {
+				return nil
+			}
+
+			fmt.Println("\nProvides:")
+			for _, file := range provided {
+				fmt.Printf("  %s\n", file)
+			}
+			return nil
+		},
 	}
+}
 
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "y" || response == "yes", nil
+// providedFiles picks the user-facing files from `brew list` output: the
+// binaries of a formula, or every artifact of a cask
+func providedFiles(output string, pkgType PackageType) []string {
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if pkgType == PackageTypeCask {
+			files = append(files, line)
+			continue
+		}
+		if strings.Contains(line, "/bin/") || strings.Contains(line, "/sbin/") {
+			files = append(files, filepath.Base(line))
+		}
+	}
+	return files
+}
+
+// cleanupSizePattern matches the space brew cleanup reports it would free or has freed
+var cleanupSizePattern = regexp.MustCompile(`approximately ([\d.]+\s*[KMGT]?B)`)
+
+// NewSetupCleanupCmd creates the cleanup command
+func NewSetupCleanupCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "cleanup",
+		Usage: "Show reclaimable Homebrew disk space and optionally clean it up",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "autoremove",
+				Usage: "Also remove dependencies no installed package needs (brew autoremove)",
+			},
+			&ufcli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Clean up without asking for confirmation",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+
+			fmt.Println("Checking reclaimable space...")
+			output, err := shell.Run(ctx, "brew", "cleanup", "-n")
+			if err != nil {
+				return fmt.Errorf("brew cleanup dry run failed: %w\n%s", err, output)
+			}
+
+			var orphans string
+			if c.Bool("autoremove") {
+				orphans, err = shell.Run(ctx, "brew", "autoremove", "--dry-run")
+				if err != nil {
+					return fmt.Errorf("brew autoremove dry run failed: %w\n%s", err, orphans)
+				}
+			}
+
+			match := cleanupSizePattern.FindStringSubmatch(output)
+			if match == nil && orphans == "" {
+				fmt.Println("✓ Nothing to clean up")
+				return nil
+			}
+			if match != nil {
+				fmt.Printf("brew cleanup would free approximately %s\n", match[1])
+			}
+			if orphans != "" {
+				fmt.Println("\nUnused dependencies:")
+				fmt.Println(orphans)
+			}
+			fmt.Println()
+
+			if !c.Bool("yes") {
+				confirm, err := prompt.Confirm("Would you like to clean up now?")
+				if errors.Is(err, prompt.ErrNotInteractive) {
+					return fmt.Errorf("%w; pass --yes to clean up without confirmation", err)
+				}
+				if err != nil {
+					return fmt.Errorf("error reading input: %w", err)
+				}
+				if !confirm {
+					fmt.Println("Cleanup cancelled")
+					return nil
+				}
+			}
+
+			if orphans != "" {
+				if output, err := shell.Run(ctx, "brew", "autoremove"); err != nil {
+					return fmt.Errorf("brew autoremove failed: %w\n%s", err, output)
+				}
+				fmt.Println("✓ Removed unused dependencies")
+			}
+
+			output, err = shell.Run(ctx, "brew", "cleanup")
+			if err != nil {
+				return fmt.Errorf("brew cleanup failed: %w\n%s", err, output)
+			}
+			if match := cleanupSizePattern.FindStringSubmatch(output); match != nil {
+				fmt.Printf("✓ Freed approximately %s\n", match[1])
+			} else {
+				fmt.Println("✓ Cleanup complete")
+			}
+			return nil
+		},
+	}
+}
+
+// hasLinkConflict reports whether brew output indicates a failed symlink step
+func hasLinkConflict(output string) bool {
+	return strings.Contains(output, "Could not symlink") || strings.Contains(output, "brew link --overwrite")
 }
 
 // NewSetupCmd creates the setup command
@@ -268,6 +736,24 @@ func NewSetupCmd() *ufcli.Command {
 	return &ufcli.Command{
 		Name:  "setup",
 		Usage: "Check and upgrade required Homebrew packages",
+		Subcommands: []*ufcli.Command{
+			NewSetupPinCmd(),
+			NewSetupUnpinCmd(),
+			NewSetupReinstallCmd(),
+			NewSetupCleanupCmd(),
+			NewSetupInfoCmd(),
+		},
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "trust",
+				Usage: "Run the Homebrew install script without prompting (for automation)",
+			},
+			&ufcli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Upgrade outdated packages without asking for confirmation; failed upgrades are not retried",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 
@@ -280,15 +766,21 @@ func NewSetupCmd() *ufcli.Command {
 			}
 
 			if !installed {
-				confirm, err := promptConfirmation("Homebrew is not installed. Would you like to install it now?")
-				if err != nil {
-					return fmt.Errorf("error reading input: %w", err)
-				}
-				if !confirm {
-					return fmt.Errorf("Homebrew installation cancelled")
+				trust := c.Bool("trust")
+				if !trust {
+					confirm, err := prompt.Confirm("Homebrew is not installed. Would you like to install it now?")
+					if errors.Is(err, prompt.ErrNotInteractive) {
+						return fmt.Errorf("%w; pass --trust to install Homebrew without confirmation", err)
+					}
+					if err != nil {
+						return fmt.Errorf("error reading input: %w", err)
+					}
+					if !confirm {
+						return fmt.Errorf("Homebrew installation cancelled")
+					}
 				}
 
-				if err := installHomebrew(ctx); err != nil {
+				if err := installHomebrew(ctx, trust); err != nil {
 					return err
 				}
 			} else {
@@ -297,20 +789,62 @@ func NewSetupCmd() *ufcli.Command {
 
 			fmt.Println("\nChecking required packages...")
 
+			// Pinned formulae are reported but never upgraded
+			pinned, err := getPinnedPackages(ctx)
+			if err != nil {
+				fmt.Printf("⚠ Warning: Could not list pinned packages: %v\n", err)
+			}
+
 			// Collect package information
 			var packageInfos []PackageInfo
 			var packagesToUpgrade []PackageInfo
+			var pinnedOutdated int // Outdated but pinned, reported as skipped
+
+			requiredPackages, err := loadPackages()
+			if err != nil {
+				return err
+			}
+
+			// Packages from private taps can only be found once the tap is added
+			var taps map[string]bool
+			for _, reqPkg := range requiredPackages {
+				if reqPkg.Tap != "" {
+					if taps, err = getTaps(ctx); err != nil {
+						return err
+					}
+					break
+				}
+			}
 
-			for _, reqPkg := range RequiredPackages {
+			var displayNames []string
+			for _, reqPkg := range requiredPackages {
+				displayNames = append(displayNames, reqPkg.DisplayName)
+			}
+			progress := newProgressTracker(ctx, displayNames)
+
+			for i, reqPkg := range requiredPackages {
 				pkgInfo := PackageInfo{
 					Name:        reqPkg.Name,
 					DisplayName: reqPkg.DisplayName,
 				}
 
+				if reqPkg.Tap != "" {
+					progress.Update(i, "tapping "+reqPkg.Tap)
+					if err := ensureTap(ctx, reqPkg.Tap, taps); err != nil {
+						progress.Warn(i, "%v", err)
+						progress.Update(i, "unknown")
+						pkgInfo.Type = PackageTypeUnknown
+						packageInfos = append(packageInfos, pkgInfo)
+						continue
+					}
+				}
+				progress.Update(i, "checking")
+
 				// Detect package type
 				pkgType, err := detectPackageType(ctx, reqPkg.Name)
 				if err != nil {
-					fmt.Printf("⚠ Warning: Could not detect type for %s: %v\n", reqPkg.DisplayName, err)
+					progress.Warn(i, "could not detect type: %v", err)
+					progress.Update(i, "unknown")
 					pkgInfo.Type = PackageTypeUnknown
 					packageInfos = append(packageInfos, pkgInfo)
 					continue
@@ -320,15 +854,17 @@ func NewSetupCmd() *ufcli.Command {
 				// Check if installed
 				installed, err := checkPackageInstalled(ctx, reqPkg.Name, pkgType)
 				if err != nil {
-					fmt.Printf("⚠ Warning: Error checking installation for %s: %v\n", reqPkg.DisplayName, err)
+					progress.Warn(i, "error checking installation: %v", err)
 				}
 				pkgInfo.Installed = installed
 
 				if installed {
+					progress.Update(i, "checking versions")
+
 					// Get current version
 					currentVer, err := getPackageVersion(ctx, reqPkg.Name, pkgType)
 					if err != nil {
-						fmt.Printf("⚠ Warning: Could not get version for %s: %v\n", reqPkg.DisplayName, err)
+						progress.Warn(i, "could not get version: %v", err)
 						currentVer = "unknown"
 					}
 					pkgInfo.CurrentVersion = currentVer
@@ -336,15 +872,24 @@ func NewSetupCmd() *ufcli.Command {
 					// Get latest version
 					latestVer, err := getLatestVersion(ctx, reqPkg.Name, pkgType)
 					if err != nil {
-						fmt.Printf("⚠ Warning: Could not get latest version for %s: %v\n", reqPkg.DisplayName, err)
+						progress.Warn(i, "could not get latest version: %v", err)
 						latestVer = "unknown"
 					}
 					pkgInfo.LatestVersion = latestVer
 
-					// Check if upgrade is needed
-					if compareVersions(currentVer, latestVer) {
+					// Check if upgrade is needed, skipping pinned formulae
+					if pinned[reqPkg.Name] {
+						pkgInfo.Pinned = true
+						if compareVersions(currentVer, latestVer) {
+							pinnedOutdated++
+						}
+						progress.Update(i, "pinned")
+					} else if compareVersions(currentVer, latestVer) {
 						pkgInfo.NeedsUpgrade = true
 						packagesToUpgrade = append(packagesToUpgrade, pkgInfo)
+						progress.Update(i, "upgrade available")
+					} else {
+						progress.Update(i, "installed")
 					}
 				} else {
 					pkgInfo.NeedsInstall = true
@@ -353,10 +898,12 @@ func NewSetupCmd() *ufcli.Command {
 					if err == nil {
 						pkgInfo.LatestVersion = latestVer
 					}
+					progress.Update(i, "not installed")
 				}
 
 				packageInfos = append(packageInfos, pkgInfo)
 			}
+			progress.Finish()
 
 			// Display summary table
 			printSummaryTable(packageInfos)
@@ -369,23 +916,42 @@ func NewSetupCmd() *ufcli.Command {
 				}
 				fmt.Println()
 
-				confirm, err := promptConfirmation("Would you like to upgrade these packages?")
-				if err != nil {
-					return fmt.Errorf("error reading input: %w", err)
+				confirm := c.Bool("yes")
+				if !confirm {
+					confirm, err = prompt.Confirm("Would you like to upgrade these packages?")
+					if errors.Is(err, prompt.ErrNotInteractive) {
+						return fmt.Errorf("%w; pass --yes to upgrade without confirmation", err)
+					}
+					if err != nil {
+						return fmt.Errorf("error reading input: %w", err)
+					}
 				}
 
 				if confirm {
 					fmt.Println("\nUpgrading packages...")
-					for _, pkg := range packagesToUpgrade {
-						if err := upgradePackage(ctx, pkg.Name, pkg.Type); err != nil {
-							fmt.Printf("✗ Error upgrading %s: %v\n", pkg.DisplayName, err)
+					failed := upgradePackages(ctx, packagesToUpgrade)
+
+					// Offer to retry failures, which are often transient
+					for len(failed) > 0 && !c.Bool("yes") {
+						fmt.Printf("\n%d package(s) failed to upgrade:\n", len(failed))
+						for _, pkg := range failed {
+							fmt.Printf("  - %s\n", pkg.DisplayName)
 						}
-						// Small delay to make progress visible
-						time.Sleep(500 * time.Millisecond)
+						retry, err := prompt.Confirm("Would you like to retry the failed packages?")
+						if err != nil {
+							return fmt.Errorf("error reading input: %w", err)
+						}
+						if !retry {
+							break
+						}
+						failed = upgradePackages(ctx, failed)
 					}
-					fmt.Println("\n✓ Package upgrades completed")
+
+					fmt.Printf("\nUpgrade summary: %d succeeded, %d failed, %d skipped\n",
+						len(packagesToUpgrade)-len(failed), len(failed), pinnedOutdated)
 				} else {
 					fmt.Println("Upgrade cancelled")
+					fmt.Printf("\nUpgrade summary: 0 succeeded, 0 failed, %d skipped\n", len(packagesToUpgrade)+pinnedOutdated)
 				}
 			} else {
 				fmt.Println("All installed packages are up to date!")
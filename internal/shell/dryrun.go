@@ -0,0 +1,173 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// dryRunKey is the context key that marks commands as dry-run only
+type dryRunKey struct{}
+
+// WithDryRun returns a context whose state-changing commands are printed
+// instead of executed. Read-only queries still run so commands can decide
+// what they would do.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether state-changing commands run with ctx are only
+// printed. Callers that write files themselves must check it too.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// printDryRun prints the command line that would have run. Commands that
+// change remote state or discard work are called out so they stand out.
+func printDryRun(command string, args []string) {
+	line := QuoteCommand(command, args...)
+	if isDestructive(command, args) {
+		fmt.Printf("[dry-run] ⚠ DESTRUCTIVE: %s\n", line)
+		return
+	}
+	fmt.Printf("[dry-run] %s\n", line)
+}
+
+// QuoteCommand formats a command line so it can be pasted into a shell
+func QuoteCommand(command string, args ...string) string {
+	parts := []string{quoteArg(command)}
+	for _, arg := range args {
+		parts = append(parts, quoteArg(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteArg single-quotes an argument if it contains shell metacharacters
+func quoteArg(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(arg, " \t\n'\"\\$`|&;<>()*?[]{}~#!") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// isDestructive reports whether a command rewrites remote history, destroys
+// infrastructure, or discards local work
+func isDestructive(command string, args []string) bool {
+	has := func(flags ...string) bool {
+		for _, flag := range flags {
+			if slices.Contains(args, flag) {
+				return true
+			}
+		}
+		return false
+	}
+	sub := ""
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			sub = arg
+			break
+		}
+	}
+
+	switch command {
+	case "git":
+		switch sub {
+		case "push":
+			return has("-f", "--force", "--force-with-lease", "--delete", "-d")
+		case "reset":
+			return has("--hard")
+		case "clean":
+			return true
+		case "branch":
+			return has("-D", "-d", "--delete")
+		case "stash":
+			return has("drop", "clear")
+		}
+	case "terraform":
+		return sub == "destroy" || sub == "apply" || has("rm", "mv") && sub == "state"
+	case "brew":
+		return sub == "uninstall" || sub == "cleanup" || sub == "autoremove"
+	}
+	return false
+}
+
+// skipInDryRun reports whether a command is skipped (and printed) in dry-run
+func skipInDryRun(ctx context.Context, command string, args []string) bool {
+	return IsDryRun(ctx) && !isReadOnly(command, args)
+}
+
+// readOnlyGit are git subcommands that never change the repository
+var readOnlyGit = []string{
+	"rev-parse", "rev-list", "log", "show", "show-ref", "diff", "status", "merge-base",
+	"cherry", "for-each-ref", "blame", "shortlog", "ls-files", "describe",
+	// commit-tree only writes an unreferenced object
+	"commit-tree",
+}
+
+// readOnlyTerraform are terraform subcommands that never change state or files
+var readOnlyTerraform = []string{"show", "version", "output", "providers", "graph", "validate"}
+
+// isReadOnly reports whether a command only queries state, so it is safe
+// to run during a dry run
+func isReadOnly(command string, args []string) bool {
+	has := func(flags ...string) bool {
+		for _, flag := range flags {
+			if slices.Contains(args, flag) {
+				return true
+			}
+		}
+		return false
+	}
+	var positional []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+		}
+	}
+	sub, rest := "", positional
+	if len(positional) > 0 {
+		sub, rest = positional[0], positional[1:]
+	}
+	next := ""
+	if len(rest) > 0 {
+		next = rest[0]
+	}
+
+	switch command {
+	case "git":
+		switch sub {
+		case "config":
+			return has("--get", "--get-all", "--list", "-l") ||
+				len(rest) == 1 && !has("--add", "--unset", "--unset-all", "--replace-all")
+		case "branch":
+			return has("--merged", "--no-merged", "--list", "--show-current")
+		case "tag":
+			return has("--list", "-l")
+		case "stash":
+			return next == "list" || next == "show"
+		case "worktree":
+			return next == "list"
+		case "symbolic-ref":
+			return len(rest) == 1
+		}
+		return slices.Contains(readOnlyGit, sub)
+	case "terraform":
+		switch sub {
+		case "state", "workspace":
+			return next == "list" || next == "show"
+		case "fmt":
+			return has("-check")
+		}
+		return slices.Contains(readOnlyTerraform, sub)
+	case "gh":
+		return sub == "pr" && (next == "view" || next == "list")
+	case "brew":
+		return sub == "info" || sub == "list" || has("--version") || sub == "tap" && len(rest) == 0
+	}
+	return false
+}
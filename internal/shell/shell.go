@@ -1,21 +1,53 @@
 package shell
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 )
 
 // Run executes a command and returns the output
 func Run(ctx context.Context, command string, args ...string) (string, error) {
+	defer record(ctx, command, args, time.Now())
+	if skipInDryRun(ctx, command, args) {
+		printDryRun(command, args)
+		return "", nil
+	}
+	defer logCommand(ctx, Dir(ctx), command, args)()
 	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = Dir(ctx)
 	output, err := cmd.CombinedOutput()
 	return strings.TrimSpace(string(output)), err
 }
 
+// RunStdout executes a command and returns only its stdout, passing stderr
+// through to the terminal. Use it when the output is parsed, e.g. as JSON.
+func RunStdout(ctx context.Context, command string, args ...string) (string, error) {
+	defer record(ctx, command, args, time.Now())
+	if skipInDryRun(ctx, command, args) {
+		printDryRun(command, args)
+		return "", nil
+	}
+	defer logCommand(ctx, Dir(ctx), command, args)()
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = Dir(ctx)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	return strings.TrimSpace(string(output)), err
+}
+
 // RunWithDir executes a command in a specific directory
 func RunWithDir(ctx context.Context, dir, command string, args ...string) (string, error) {
+	defer record(ctx, command, args, time.Now())
+	if skipInDryRun(ctx, command, args) {
+		printDryRun(command, args)
+		return "", nil
+	}
+	defer logCommand(ctx, dir, command, args)()
 	cmd := exec.CommandContext(ctx, command, args...)
 	cmd.Dir = dir
 	output, err := cmd.CombinedOutput()
@@ -24,13 +56,79 @@ func RunWithDir(ctx context.Context, dir, command string, args ...string) (strin
 
 // RunInteractive executes a command with stdin/stdout/stderr passthrough
 func RunInteractive(ctx context.Context, command string, args ...string) error {
+	defer record(ctx, command, args, time.Now())
+	if skipInDryRun(ctx, command, args) {
+		printDryRun(command, args)
+		return nil
+	}
+	defer logCommand(ctx, Dir(ctx), command, args)()
 	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = Dir(ctx)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
 
+// RunTee executes a command, streaming its output to the terminal while also
+// capturing it. Stdout and stderr are combined in the returned output.
+func RunTee(ctx context.Context, command string, args ...string) (string, error) {
+	defer record(ctx, command, args, time.Now())
+	if skipInDryRun(ctx, command, args) {
+		printDryRun(command, args)
+		return "", nil
+	}
+	defer logCommand(ctx, Dir(ctx), command, args)()
+	var buf bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = Dir(ctx)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = io.MultiWriter(os.Stdout, &buf)
+	cmd.Stderr = io.MultiWriter(os.Stderr, &buf)
+	err := cmd.Run()
+	return strings.TrimSpace(buf.String()), err
+}
+
+// RunInteractiveWithEnv executes a command with stdin/stdout/stderr passthrough,
+// adding the given KEY=value pairs to the inherited environment
+func RunInteractiveWithEnv(ctx context.Context, env []string, command string, args ...string) error {
+	defer record(ctx, command, args, time.Now())
+	if skipInDryRun(ctx, command, args) {
+		printDryRun(command, args)
+		return nil
+	}
+	defer logCommand(ctx, Dir(ctx), command, args)()
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = Dir(ctx)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// dirKey is the context key for the working directory commands run in
+type dirKey struct{}
+
+// WithDir returns a context whose commands run in dir instead of the
+// current directory. RunWithDir's explicit dir still takes precedence.
+func WithDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, dirKey{}, dir)
+}
+
+// Dir returns the working directory set with WithDir, or "" for the
+// current directory
+func Dir(ctx context.Context) string {
+	dir, _ := ctx.Value(dirKey{}).(string)
+	return dir
+}
+
+// CommandExists reports whether a command is available on the PATH
+func CommandExists(command string) bool {
+	_, err := exec.LookPath(command)
+	return err == nil
+}
+
 // ExitCode extracts the exit code from an error
 func ExitCode(err error) int {
 	if err == nil {
@@ -0,0 +1,27 @@
+package shell
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// IsTerminal reports whether the given file descriptor is a terminal
+func IsTerminal(fd uintptr) bool {
+	return term.IsTerminal(int(fd))
+}
+
+// StdinIsTTY reports whether stdin is a terminal, i.e. the user can answer prompts
+func StdinIsTTY() bool {
+	return IsTerminal(os.Stdin.Fd())
+}
+
+// StdoutIsTTY reports whether stdout is a terminal
+func StdoutIsTTY() bool {
+	return IsTerminal(os.Stdout.Fd())
+}
+
+// StderrIsTTY reports whether stderr is a terminal
+func StderrIsTTY() bool {
+	return IsTerminal(os.Stderr.Fd())
+}
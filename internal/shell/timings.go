@@ -0,0 +1,76 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timingsKey is the context key for the active Timings collector
+type timingsKey struct{}
+
+// Timing is the wall-clock duration of a single command invocation
+type Timing struct {
+	Command  string
+	Args     []string
+	Duration time.Duration
+}
+
+// Timings collects the duration of every command run with a context
+// returned by WithTimings
+type Timings struct {
+	mu      sync.Mutex
+	entries []Timing
+}
+
+// WithTimings returns a context that records command durations into the
+// returned collector
+func WithTimings(ctx context.Context) (context.Context, *Timings) {
+	t := &Timings{}
+	return context.WithValue(ctx, timingsKey{}, t), t
+}
+
+// record adds a command's duration to the context's collector, if any
+func record(ctx context.Context, command string, args []string, start time.Time) {
+	t, ok := ctx.Value(timingsKey{}).(*Timings)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, Timing{Command: command, Args: args, Duration: time.Since(start)})
+}
+
+// Print writes the recorded commands, slowest first, followed by the total
+func (t *Timings) Print(w io.Writer) {
+	t.mu.Lock()
+	entries := append([]Timing{}, t.entries...)
+	t.mu.Unlock()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Duration > entries[j].Duration
+	})
+
+	var total time.Duration
+	fmt.Fprintln(w, "\nTimings:")
+	fmt.Fprintf(w, "%10s  %s\n", "Duration", "Command")
+	for _, e := range entries {
+		total += e.Duration
+		fmt.Fprintf(w, "%10s  %s\n", e.Duration.Round(time.Millisecond), summarizeCommand(e.Command, e.Args))
+	}
+	fmt.Fprintf(w, "%10s  total across %d command(s)\n", total.Round(time.Millisecond), len(entries))
+}
+
+// summarizeCommand joins a command line, truncating long argument lists
+func summarizeCommand(command string, args []string) string {
+	const maxLen = 70
+	line := strings.Join(append([]string{command}, args...), " ")
+	if len(line) > maxLen {
+		return line[:maxLen-3] + "..."
+	}
+	return line
+}
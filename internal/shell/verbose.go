@@ -0,0 +1,41 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// verboseKey is the context key that enables command logging
+type verboseKey struct{}
+
+// WithVerbose returns a context whose commands are logged to stderr, with
+// their working directory and elapsed time
+func WithVerbose(ctx context.Context) context.Context {
+	return context.WithValue(ctx, verboseKey{}, true)
+}
+
+// IsVerbose reports whether commands run with ctx are logged
+func IsVerbose(ctx context.Context) bool {
+	verbose, _ := ctx.Value(verboseKey{}).(bool)
+	return verbose
+}
+
+// logCommand logs a command before it runs and returns a function that logs
+// how long it took, meant to be deferred. It does nothing unless verbose.
+func logCommand(ctx context.Context, dir, command string, args []string) func() {
+	if !IsVerbose(ctx) {
+		return func() {}
+	}
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	line := QuoteCommand(command, args...)
+	fmt.Fprintf(os.Stderr, "[verbose] $ %s (in %s)\n", line, dir)
+
+	start := time.Now()
+	return func() {
+		fmt.Fprintf(os.Stderr, "[verbose] %s finished in %s\n", line, time.Since(start).Round(time.Millisecond))
+	}
+}
@@ -0,0 +1,211 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// graphEdgePattern matches an edge in `terraform graph` DOT output
+var graphEdgePattern = regexp.MustCompile(`"([^"]+)"\s*->\s*"([^"]+)"`)
+
+// graphResourcePattern matches resource, data source, and module addresses
+var graphResourcePattern = regexp.MustCompile(`^(module\.[\w-]+\.)*((data\.)?[A-Za-z_][\w-]*\.[\w-]+|module\.[\w-]+)$`)
+
+// providerDeps holds the resources and dependency edges for one provider directory
+type providerDeps struct {
+	Name      string
+	Resources []string
+	DependsOn map[string][]string
+	Err       error
+}
+
+// NewTerraformDepsCmd creates the deps command.
+// Runs `terraform graph` in each provider subdirectory of a multi-provider
+// resource and combines the results into one report, so cross-provider
+// structure can be reviewed in one place.
+func NewTerraformDepsCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "deps",
+		Usage:     "Report resources and dependencies for each provider subdirectory",
+		ArgsUsage: "<dir>",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "format",
+				Usage: "Output format: text or dot",
+				Value: "text",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+			if c.NArg() < 1 {
+				return fmt.Errorf("directory is required")
+			}
+
+			format := c.String("format")
+			if format != "text" && format != "dot" {
+				return fmt.Errorf("format must be 'text' or 'dot', got %s", format)
+			}
+
+			safePath, err := validatePath(c.Args().First())
+			if err != nil {
+				return err
+			}
+
+			dirs, err := findProviderDirs(safePath)
+			if err != nil {
+				return err
+			}
+			if len(dirs) == 0 {
+				return fmt.Errorf("no subdirectories containing .tf files found in %s", safePath)
+			}
+
+			var report []providerDeps
+			for _, dir := range dirs {
+				report = append(report, collectDeps(ctx, safePath, dir))
+			}
+
+			if format == "dot" {
+				printDepsDOT(report)
+			} else {
+				printDepsText(report)
+			}
+
+			for _, deps := range report {
+				if deps.Err != nil {
+					return fmt.Errorf("failed to graph one or more provider directories")
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// findProviderDirs returns the immediate subdirectories of root that contain .tf files
+func findProviderDirs(root string) ([]string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", root, err)
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(root, entry.Name(), "*.tf"))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) > 0 {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	return dirs, nil
+}
+
+// collectDeps runs terraform graph in a provider directory and parses the result
+func collectDeps(ctx context.Context, root, dir string) providerDeps {
+	deps := providerDeps{Name: dir, DependsOn: map[string][]string{}}
+
+	output, err := shell.Run(ctx, "terraform", "-chdir="+filepath.Join(root, dir), "graph")
+	if err != nil {
+		deps.Err = fmt.Errorf("%w\n%s", err, output)
+		return deps
+	}
+
+	resources := map[string]bool{}
+	for _, match := range graphEdgePattern.FindAllStringSubmatch(output, -1) {
+		from, to := graphNodeAddress(match[1]), graphNodeAddress(match[2])
+		if from != "" {
+			resources[from] = true
+		}
+		if to != "" {
+			resources[to] = true
+		}
+		if from != "" && to != "" && from != to {
+			deps.DependsOn[from] = append(deps.DependsOn[from], to)
+		}
+	}
+
+	for resource := range resources {
+		deps.Resources = append(deps.Resources, resource)
+	}
+	sort.Strings(deps.Resources)
+	for from := range deps.DependsOn {
+		sort.Strings(deps.DependsOn[from])
+	}
+	return deps
+}
+
+// graphNodeAddress strips terraform graph decorations from a node label and
+// returns the resource address, or "" for providers, variables, and other
+// non-resource nodes
+func graphNodeAddress(label string) string {
+	label = strings.TrimPrefix(label, "[root] ")
+	label = strings.TrimSuffix(label, " (expand)")
+	label = strings.TrimSuffix(label, " (close)")
+	for _, prefix := range []string{"var.", "local.", "output."} {
+		if strings.HasPrefix(label, prefix) {
+			return ""
+		}
+	}
+	if !graphResourcePattern.MatchString(label) {
+		return ""
+	}
+	return label
+}
+
+// printDepsText prints a human-readable report grouped by provider directory
+func printDepsText(report []providerDeps) {
+	for i, deps := range report {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s:\n", deps.Name)
+		if deps.Err != nil {
+			fmt.Printf("  ✗ terraform graph failed: %v\n", deps.Err)
+			continue
+		}
+		if len(deps.Resources) == 0 {
+			fmt.Println("  (no resources)")
+			continue
+		}
+		for _, resource := range deps.Resources {
+			fmt.Printf("  %s\n", resource)
+			for _, target := range deps.DependsOn[resource] {
+				fmt.Printf("    -> %s\n", target)
+			}
+		}
+	}
+}
+
+// printDepsDOT prints a combined GraphViz graph with one cluster per provider directory
+func printDepsDOT(report []providerDeps) {
+	fmt.Println("digraph deps {")
+	fmt.Println("  rankdir = \"RL\";")
+	for _, deps := range report {
+		if deps.Err != nil {
+			continue
+		}
+		fmt.Printf("  subgraph \"cluster_%s\" {\n", deps.Name)
+		fmt.Printf("    label = %q;\n", deps.Name)
+		for _, resource := range deps.Resources {
+			fmt.Printf("    %q [label = %q];\n", deps.Name+"/"+resource, resource)
+		}
+		fmt.Println("  }")
+		for _, resource := range deps.Resources {
+			for _, target := range deps.DependsOn[resource] {
+				fmt.Printf("  %q -> %q;\n", deps.Name+"/"+resource, deps.Name+"/"+target)
+			}
+		}
+	}
+	fmt.Println("}")
+}
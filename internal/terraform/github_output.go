@@ -0,0 +1,117 @@
+package terraform
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+)
+
+// terraformOutput is one entry from `terraform output -json`
+type terraformOutput struct {
+	Sensitive bool            `json:"sensitive"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// writeGitHubOutputs appends every output to the file named by GITHUB_OUTPUT
+// so later steps can read them as steps.<id>.outputs.<name>. Sensitive
+// outputs are skipped unless includeSensitive is set, in which case their
+// values are masked in the job log first.
+func writeGitHubOutputs(ctx context.Context, includeSensitive bool) error {
+	outputPath := os.Getenv("GITHUB_OUTPUT")
+	if outputPath == "" {
+		return fmt.Errorf("GITHUB_OUTPUT is not set; --github-output only works inside a GitHub Actions step")
+	}
+
+	// Stdout only: warnings on stderr would break the JSON
+	output, err := shell.RunStdout(ctx, "terraform", "output", "-json")
+	if err != nil {
+		return fmt.Errorf("failed to read outputs: %w", err)
+	}
+	var outputs map[string]terraformOutput
+	if err := json.Unmarshal([]byte(output), &outputs); err != nil {
+		return fmt.Errorf("failed to parse terraform output: %w", err)
+	}
+
+	var b strings.Builder
+	written, skipped := 0, 0
+	for _, name := range sortedKeys(outputs) {
+		out := outputs[name]
+		if out.Sensitive && !includeSensitive {
+			skipped++
+			continue
+		}
+		value := outputValue(out.Value)
+		if out.Sensitive {
+			fmt.Print(addMaskCommands(value))
+		}
+		line, err := formatGitHubOutput(name, value)
+		if err != nil {
+			return err
+		}
+		b.WriteString(line)
+		written++
+	}
+
+	if shell.IsDryRun(ctx) {
+		fmt.Printf("Would write %d output(s) to GITHUB_OUTPUT\n", written)
+		return nil
+	}
+	f, err := os.OpenFile(outputPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", outputPath, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(b.String()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	fmt.Printf("✓ Wrote %d output(s) to GITHUB_OUTPUT\n", written)
+	if skipped > 0 {
+		fmt.Printf("Skipped %d sensitive output(s); use --include-sensitive to write them\n", skipped)
+	}
+	return nil
+}
+
+// outputValue returns strings unquoted and any other value as compact JSON
+func outputValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// addMaskCommands returns the ::add-mask:: workflow commands that hide value
+// in the job log. Each line is masked separately, since GitHub matches masks
+// line by line.
+func addMaskCommands(value string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		b.WriteString("::add-mask::" + strings.ReplaceAll(line, "%", "%25") + "\n")
+	}
+	return b.String()
+}
+
+// formatGitHubOutput formats a name=value line, using the heredoc syntax
+// with a random delimiter when the value spans multiple lines
+func formatGitHubOutput(name, value string) (string, error) {
+	if !strings.ContainsAny(value, "\r\n") {
+		return fmt.Sprintf("%s=%s\n", name, value), nil
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate delimiter: %w", err)
+	}
+	delimiter := "ghadelimiter_" + hex.EncodeToString(buf)
+	return fmt.Sprintf("%s<<%s\n%s\n%s\n", name, delimiter, value, delimiter), nil
+}
@@ -0,0 +1,149 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// stateAddressPattern extracts the resource type from a state address,
+// skipping any module path, e.g. module.vpc.aws_subnet.private["a"]
+var stateAddressPattern = regexp.MustCompile(`^(?:module\.[\w-]+(?:\[[^\]]*\])?\.)*(data\.)?([\w-]+)\.`)
+
+// driftPattern matches a resource reported by a refresh-only plan
+var driftPattern = regexp.MustCompile(`(?m)^\s*# (\S+) has (?:changed|been deleted)`)
+
+// stateOverview summarizes the resources tracked in state
+type stateOverview struct {
+	Managed     int
+	DataSources int
+	ByType      map[string]int
+}
+
+// NewTerraformOverviewCmd creates the overview command.
+// Combines the current workspace, backend type, resource counts from state,
+// and a refresh-only drift check into one read-only dashboard.
+func NewTerraformOverviewCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "overview",
+		Usage: "Show workspace, backend, resource counts by type, and drift at a glance",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.BoolFlag{
+				Name:  "skip-drift",
+				Usage: "Skip the refresh-only drift check, which queries the providers",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+			safePath, err := validatePath(c.String("path"))
+			if err != nil {
+				return err
+			}
+
+			workspace, err := shell.Run(ctx, "terraform", chdirArgs(safePath, "workspace", "show")...)
+			if err != nil {
+				return fmt.Errorf("failed to read workspace: %w\n%s", err, workspace)
+			}
+
+			output, err := shell.Run(ctx, "terraform", chdirArgs(safePath, "state", "list")...)
+			if err != nil {
+				return fmt.Errorf("failed to list state: %w\n%s", err, output)
+			}
+			state := summarizeState(output)
+
+			absPath, _ := filepath.Abs(safePath)
+			fmt.Printf("Terraform overview: %s\n\n", absPath)
+			fmt.Printf("  Workspace:  %s\n", workspace)
+			fmt.Printf("  Backend:    %s\n", readBackendType(safePath))
+			fmt.Printf("  Resources:  %d managed, %d data source(s)\n", state.Managed, state.DataSources)
+
+			if len(state.ByType) > 0 {
+				types := sortedKeys(state.ByType)
+				sort.SliceStable(types, func(i, j int) bool {
+					return state.ByType[types[i]] > state.ByType[types[j]]
+				})
+				fmt.Println()
+				for _, t := range types {
+					fmt.Printf("  %5d  %s\n", state.ByType[t], t)
+				}
+			}
+
+			fmt.Println()
+			if c.Bool("skip-drift") {
+				fmt.Println("  Drift:      (skipped)")
+				return nil
+			}
+			return printDrift(ctx, safePath)
+		},
+	}
+}
+
+// summarizeState counts `terraform state list` addresses by resource type
+func summarizeState(output string) stateOverview {
+	state := stateOverview{ByType: map[string]int{}}
+	for _, line := range strings.Split(output, "\n") {
+		match := stateAddressPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		if match[1] != "" {
+			state.DataSources++
+			state.ByType["data."+match[2]]++
+			continue
+		}
+		state.Managed++
+		state.ByType[match[2]]++
+	}
+	return state
+}
+
+// readBackendType returns the backend recorded by terraform init in dir,
+// or "local" when no remote backend has been initialized
+func readBackendType(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, ".terraform", "terraform.tfstate"))
+	if err != nil {
+		return "local"
+	}
+	var initState struct {
+		Backend struct {
+			Type string `json:"type"`
+		} `json:"backend"`
+	}
+	if err := json.Unmarshal(data, &initState); err != nil || initState.Backend.Type == "" {
+		return "local"
+	}
+	return initState.Backend.Type
+}
+
+// printDrift runs a refresh-only plan, which never writes state, and reports
+// resources changed outside of Terraform
+func printDrift(ctx context.Context, dir string) error {
+	// With -detailed-exitcode, exit code 2 means the refresh found changes
+	output, err := shell.Run(ctx, "terraform", chdirArgs(dir, "plan", "-refresh-only", "-detailed-exitcode", "-input=false", "-lock=false", "-no-color")...)
+	switch shell.ExitCode(err) {
+	case 0:
+		fmt.Println("  Drift:      ✓ none")
+		return nil
+	case 2:
+		drifted := driftPattern.FindAllStringSubmatch(output, -1)
+		fmt.Printf("  Drift:      ⚠ %d resource(s) changed outside of Terraform\n", len(drifted))
+		for _, match := range drifted {
+			fmt.Printf("              - %s\n", match[1])
+		}
+		return nil
+	default:
+		return fmt.Errorf("drift check failed: %w\n%s", err, output)
+	}
+}
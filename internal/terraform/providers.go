@@ -0,0 +1,182 @@
+package terraform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// requiredProvidersPattern matches the start of a required_providers block
+var requiredProvidersPattern = regexp.MustCompile(`required_providers\s*\{`)
+
+// providerEntryPattern matches `name = { ... }` entries inside required_providers
+var providerEntryPattern = regexp.MustCompile(`(?s)([A-Za-z][\w-]*)\s*=\s*\{(.*?)\}`)
+
+// legacyProviderPattern matches the pre-0.13 `name = "version"` shorthand
+var legacyProviderPattern = regexp.MustCompile(`(?m)^\s*([A-Za-z][\w-]*)\s*=\s*"([^"]*)"`)
+
+// providerAttrPattern matches a quoted attribute such as source or version
+var providerAttrPattern = regexp.MustCompile(`(source|version)\s*=\s*"([^"]*)"`)
+
+// sourceSegmentPattern matches a namespace or type segment of a provider source
+var sourceSegmentPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+
+// versionConstraintPattern matches a single version constraint, e.g. "~> 5.0"
+var versionConstraintPattern = regexp.MustCompile(`^(=|!=|>|>=|<|<=|~>)?\s*v?\d+(\.\d+){0,2}(-[0-9A-Za-z.-]+)?$`)
+
+// requiredProvider is one entry from a required_providers block
+type requiredProvider struct {
+	Name    string
+	Source  string
+	Version string
+	File    string
+	Problem string // Empty when the entry looks valid
+}
+
+// readRequiredProviders parses the required_providers blocks of every .tf
+// file in dir. Parsing is regex-based and meant for quick sanity checks,
+// not as a full HCL parser.
+func readRequiredProviders(dir string) ([]requiredProvider, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var providers []requiredProvider
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		for _, body := range findBlockBodies(string(data), requiredProvidersPattern) {
+			for _, match := range providerEntryPattern.FindAllStringSubmatch(body, -1) {
+				provider := requiredProvider{Name: match[1], File: filepath.Base(file)}
+				for _, attr := range providerAttrPattern.FindAllStringSubmatch(match[2], -1) {
+					if attr[1] == "source" {
+						provider.Source = attr[2]
+					} else {
+						provider.Version = attr[2]
+					}
+				}
+				provider.Problem = checkRequiredProvider(provider)
+				providers = append(providers, provider)
+			}
+
+			// Strip object entries so their attributes aren't mistaken for legacy entries
+			legacyBody := providerEntryPattern.ReplaceAllString(body, "")
+			for _, match := range legacyProviderPattern.FindAllStringSubmatch(legacyBody, -1) {
+				providers = append(providers, requiredProvider{
+					Name:    match[1],
+					Version: match[2],
+					File:    filepath.Base(file),
+					Problem: "legacy shorthand without a source; use { source = ..., version = ... }",
+				})
+			}
+		}
+	}
+	return providers, nil
+}
+
+// findBlockBodies returns the contents of every block whose opening matches
+// start, using string-aware brace matching
+func findBlockBodies(content string, start *regexp.Regexp) []string {
+	var bodies []string
+	for _, loc := range start.FindAllStringIndex(content, -1) {
+		depth := 1
+		inString := false
+		for i := loc[1]; i < len(content); i++ {
+			switch ch := content[i]; {
+			case inString && ch == '\\':
+				i++
+			case ch == '"':
+				inString = !inString
+			case !inString && ch == '{':
+				depth++
+			case !inString && ch == '}':
+				depth--
+			}
+			if depth == 0 {
+				bodies = append(bodies, content[loc[1]:i])
+				break
+			}
+		}
+	}
+	return bodies
+}
+
+// checkRequiredProvider returns a description of what is wrong with a
+// provider entry, or "" if it looks valid
+func checkRequiredProvider(p requiredProvider) string {
+	if p.Source == "" {
+		return "missing source"
+	}
+
+	parts := strings.Split(p.Source, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Sprintf("source %q must be [hostname/]namespace/type", p.Source)
+	}
+	if slices.Contains(parts, "") {
+		return fmt.Sprintf("source %q has an empty segment", p.Source)
+	}
+	if len(parts) == 3 && !strings.Contains(parts[0], ".") && parts[0] != "localhost" {
+		return fmt.Sprintf("source hostname %q is not a valid hostname", parts[0])
+	}
+	for _, segment := range parts[len(parts)-2:] {
+		if !sourceSegmentPattern.MatchString(segment) {
+			return fmt.Sprintf("source segment %q may only contain letters, digits, and dashes", segment)
+		}
+	}
+
+	if p.Version != "" {
+		for _, constraint := range strings.Split(p.Version, ",") {
+			if !versionConstraintPattern.MatchString(strings.TrimSpace(constraint)) {
+				return fmt.Sprintf("version constraint %q is malformed", p.Version)
+			}
+		}
+	}
+	return ""
+}
+
+// checkProviders prints each required provider and fails if any look malformed
+func checkProviders(dir string) error {
+	providers, err := readRequiredProviders(dir)
+	if err != nil {
+		return err
+	}
+	if len(providers) == 0 {
+		fmt.Println("No required_providers entries found")
+		return nil
+	}
+
+	fmt.Printf("%-15s %-35s %-15s %s\n", "Provider", "Source", "Version", "File")
+	fmt.Println(strings.Repeat("-", 80))
+
+	problems := 0
+	for _, p := range providers {
+		source, version := p.Source, p.Version
+		if source == "" {
+			source = "-"
+		}
+		if version == "" {
+			version = "(any)"
+		}
+		fmt.Printf("%-15s %-35s %-15s %s\n", p.Name, source, version, p.File)
+		if p.Problem != "" {
+			fmt.Printf("  ✗ %s\n", p.Problem)
+			problems++
+		}
+	}
+	fmt.Println()
+
+	if problems > 0 {
+		return fmt.Errorf("%d provider configuration problem(s) found", problems)
+	}
+	fmt.Println("✓ Provider configuration looks valid")
+	return nil
+}
@@ -1,11 +1,25 @@
 package terraform
 
 import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/christopher.carver/cc/internal/config"
+	"github.com/christopher.carver/cc/internal/prompt"
 	"github.com/christopher.carver/cc/internal/shell"
 
 	ufcli "github.com/urfave/cli/v2"
@@ -54,37 +68,359 @@ func validatePath(path string) (string, error) {
 	return absPath, nil
 }
 
+// passThroughArgs returns the arguments given after "--", which are forwarded
+// to terraform unchanged, e.g. cc terraform plan -- -var-file=prod.tfvars.
+// Relative paths in them are resolved from the --path directory. Any other
+// positional argument is an error rather than being forwarded.
+func passThroughArgs(c *ufcli.Context) ([]string, error) {
+	// The flag parser drops "--", so count what followed it on the command line
+	var after []string
+	if i := slices.Index(os.Args, "--"); i >= 0 {
+		after = os.Args[i+1:]
+	}
+	args := c.Args().Slice()
+	if stray := len(args) - len(after); stray > 0 {
+		return nil, fmt.Errorf("unexpected argument %q; pass terraform arguments after --", args[0])
+	}
+	return args, nil
+}
+
+// chdirArgs prefixes terraform args with -chdir=dir so the command runs in
+// dir, which newer terraform versions require instead of a trailing
+// directory argument. Relative paths in args are then resolved from dir.
+func chdirArgs(dir string, args ...string) []string {
+	if dir == "." {
+		return args
+	}
+	return append([]string{"-chdir=" + dir}, args...)
+}
+
+// requireApproval ensures terraform's approval prompt can be answered on a
+// terminal, unless --auto-approve was given
+func requireApproval(c *ufcli.Context, action string) error {
+	if c.Bool("auto-approve") || shell.StdinIsTTY() || shell.IsDryRun(c.Context) {
+		return nil
+	}
+	return fmt.Errorf("%s needs interactive approval but stdin is not a terminal; pass --auto-approve to run non-interactively", action)
+}
+
+// getStagedTerraformFiles returns the staged .tf files under dir, relative
+// to the current directory, skipping deletions
+func getStagedTerraformFiles(ctx context.Context, dir string) ([]string, error) {
+	output, err := shell.Run(ctx, "git", "diff", "--cached", "--name-only", "--relative", "--diff-filter=ACMR", "--", dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged files: %w", err)
+	}
+	return terraformFileList(output), nil
+}
+
+// getLocalTerraformChanges returns the .tf files under dir that differ from
+// where this branch left origin/main: committed, staged, edited in the
+// working tree, or untracked. Paths are relative to the current directory
+// and deletions are skipped.
+func getLocalTerraformChanges(ctx context.Context, dir string) ([]string, error) {
+	// Diffing the working tree against the merge-base covers committed,
+	// staged, and unstaged changes at once
+	base, err := shell.Run(ctx, "git", "merge-base", defaultDiffBase, "HEAD")
+	if err != nil {
+		// Fallback: compare with the previous commit if origin/main doesn't exist
+		base = "HEAD~1"
+	}
+	changed, err := shell.Run(ctx, "git", "diff", "--name-only", "--relative", "--diff-filter=ACMR", base, "--", dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+	untracked, err := shell.Run(ctx, "git", "ls-files", "--others", "--exclude-standard", "--", dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, file := range append(terraformFileList(changed), terraformFileList(untracked)...) {
+		seen[file] = true
+	}
+	return sortedKeys(seen), nil
+}
+
+// terraformFileList returns the .tf files from git's one-path-per-line output
+func terraformFileList(output string) []string {
+	var tfFiles []string
+	for _, file := range strings.Split(output, "\n") {
+		if file = strings.TrimSpace(file); strings.HasSuffix(file, ".tf") {
+			tfFiles = append(tfFiles, file)
+		}
+	}
+	return tfFiles
+}
+
+// stageFiles runs git add on the files terraform fmt rewrote
+func stageFiles(ctx context.Context, files []string) error {
+	if len(files) == 0 {
+		fmt.Println("No files were reformatted, nothing to stage")
+		return nil
+	}
+	if output, err := shell.Run(ctx, "git", append([]string{"add", "--"}, files...)...); err != nil {
+		return fmt.Errorf("failed to stage reformatted files: %w\n%s", err, output)
+	}
+	fmt.Printf("✓ Staged %d reformatted file(s):\n", len(files))
+	for _, file := range files {
+		fmt.Printf("  %s\n", file)
+	}
+	return nil
+}
+
+// defaultDiffBase is the ref changed files are compared against by default
+const defaultDiffBase = "origin/main"
+
+// getChangedTerraformFiles returns the .tf files changed on this branch since
+// it diverged from base (origin/main when empty), like a PR diff. Without an
+// explicit base it falls back to the last commit when origin/main is not
+// available; an explicit base that doesn't resolve is an error.
+func getChangedTerraformFiles(ctx context.Context, base string) ([]string, error) {
+	explicit := base != ""
+	if !explicit {
+		base = defaultDiffBase
+	}
+
+	// base...HEAD diffs from the merge-base, so changes already merged to
+	// base are not included when the branch is behind
+	output, err := shell.Run(ctx, "git", "diff", "--name-only", base+"...HEAD")
+	if err != nil && explicit {
+		return nil, fmt.Errorf("failed to diff against base %s (does the ref exist?): %w", base, err)
+	}
+	if err != nil {
+		// Fallback: try comparing with HEAD~1 (previous commit) if base doesn't exist
+		output, err = shell.Run(ctx, "git", "diff", "--name-only", "HEAD~1...HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get changed files: %w", err)
+		}
+	}
+
+	// Filter for only .tf files, skipping empty lines
+	var tfFiles []string
+	for _, file := range strings.Split(output, "\n") {
+		file = strings.TrimSpace(file)
+		if strings.HasSuffix(file, ".tf") {
+			tfFiles = append(tfFiles, file)
+		}
+	}
+	return tfFiles, nil
+}
+
+// planApprovalToken derives the approval token for a saved plan file
+// from the SHA-256 hash of its contents
+func planApprovalToken(planFile string) (string, error) {
+	data, err := os.ReadFile(planFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read plan file: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// verifyApproval checks that approvalFile holds the token for planFile
+func verifyApproval(planFile, approvalFile string) error {
+	data, err := os.ReadFile(approvalFile)
+	if err != nil {
+		return fmt.Errorf("plan is not approved: %w", err)
+	}
+
+	token, err := planApprovalToken(planFile)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(string(data)) != token {
+		return fmt.Errorf("approval in %s does not match plan %s; re-run approve after reviewing the plan", approvalFile, planFile)
+	}
+	return nil
+}
+
+// addressPattern matches a Terraform resource or module address, such as
+// aws_instance.web, data.aws_ami.ubuntu, module.vpc, or
+// module.vpc.aws_subnet.private["a"]
+var addressPattern = regexp.MustCompile(`^(module\.[A-Za-z_][\w-]*(\[[^\]]+\])?\.)*((data\.)?[A-Za-z_][\w-]*\.[A-Za-z_][\w-]*(\[[^\]]+\])?|module\.[A-Za-z_][\w-]*(\[[^\]]+\])?)$`)
+
+// validateAddress ensures a string is a well-formed Terraform address
+func validateAddress(address string) error {
+	if !addressPattern.MatchString(address) {
+		return fmt.Errorf("invalid Terraform address: %q", address)
+	}
+	return nil
+}
+
+// appendBlock appends an HCL block to a file in dir, creating the file if
+// needed and separating it from existing content with a blank line
+func appendBlock(dir, file, block string) (string, error) {
+	path := filepath.Join(dir, file)
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	prefix := ""
+	if len(existing) > 0 {
+		prefix = "\n"
+		if !strings.HasSuffix(string(existing), "\n") {
+			prefix = "\n\n"
+		}
+	}
+	if _, err := f.WriteString(prefix + block); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// lockedProviderPattern matches a provider block and its version in .terraform.lock.hcl
+var lockedProviderPattern = regexp.MustCompile(`provider "([^"]+)" \{\s*version\s*=\s*"([^"]+)"`)
+
+// readLockedProviders returns provider source -> locked version from a lock
+// file. A missing lock file yields an empty map.
+func readLockedProviders(lockFile string) (map[string]string, error) {
+	providers := make(map[string]string)
+
+	data, err := os.ReadFile(lockFile)
+	if os.IsNotExist(err) {
+		return providers, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	for _, match := range lockedProviderPattern.FindAllStringSubmatch(string(data), -1) {
+		providers[match[1]] = match[2]
+	}
+	return providers, nil
+}
+
+// sortedKeys returns a map's keys in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// declarationPattern matches top-level resource and module declarations
+var declarationPattern = regexp.MustCompile(`(?m)^\s*(?:resource\s+"([^"]+)"\s+"([^"]+)"|module\s+"([^"]+)")\s*\{`)
+
+// getChangedTargets returns the resource and module addresses declared in
+// changed .tf files that belong to the configuration in dir
+func getChangedTargets(ctx context.Context, dir string) ([]string, error) {
+	tfFiles, err := getChangedTerraformFiles(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Changed file paths are relative to the repository root
+	root, err := shell.Run(ctx, "git", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository root: %w", err)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var targets []string
+	for _, file := range tfFiles {
+		fullPath := filepath.Join(root, file)
+		if filepath.Dir(fullPath) != absDir {
+			continue
+		}
+		data, err := os.ReadFile(fullPath)
+		if err != nil {
+			continue // Deleted files have nothing left to target
+		}
+		for _, match := range declarationPattern.FindAllStringSubmatch(string(data), -1) {
+			target := "module." + match[3]
+			if match[3] == "" {
+				target = match[1] + "." + match[2]
+			}
+			if !seen[target] {
+				seen[target] = true
+				targets = append(targets, target)
+			}
+		}
+	}
+	return targets, nil
+}
+
+// runHook executes a user-supplied hook command through the shell,
+// streaming its output so the user can follow along. Extra KEY=value
+// pairs in env are exposed to the hook.
+func runHook(ctx context.Context, command string, env ...string) error {
+	fmt.Printf("Running hook: %s\n", command)
+	return shell.RunInteractiveWithEnv(ctx, env, "/bin/sh", "-c", command)
+}
+
+// planSummaryPattern matches terraform's "Plan: X to add, Y to change, Z to destroy." line
+var planSummaryPattern = regexp.MustCompile(`Plan: (\d+) to add, (\d+) to change, (\d+) to destroy`)
+
+// parsePlanSummary extracts the change summary line from plan output,
+// returning an empty string when the plan has no summary (e.g. no changes)
+func parsePlanSummary(output string) string {
+	return planSummaryPattern.FindString(output)
+}
+
+// ansiPattern matches ANSI escape sequences such as terminal color codes
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// stripANSI removes terminal color codes so output can be saved as plain text
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
 // ============================================================================
 // Main Command
 // ============================================================================
 
 // NewTerraformCmd creates the main terraform command with all subcommands.
-// This is the entry point for all terraform-related operations.
+// This is the entry point for all terraform-related operations. Subcommands
+// that run terraform first check its version against required_version.
 func NewTerraformCmd() *ufcli.Command {
-	return &ufcli.Command{
+	return withVersionCheck(&ufcli.Command{
 		Name:  "terraform",
 		Usage: "Terraform operations and shortcuts",
 		Subcommands: []*ufcli.Command{
 			// Basic Terraform Commands (Core Workflow)
 			NewTerraformInitCmd(),
+			NewTerraformUpgradeProvidersCmd(),
 			NewTerraformFormatCmd(),
 			NewTerraformValidateCmd(),
 			NewTerraformPlanCmd(),
 			NewTerraformApplyCmd(),
+			NewTerraformApproveCmd(),
 			NewTerraformDestroyCmd(),
 			// Security & Validation Commands
 			NewTerraformScanCmd(),
 			NewTerraformCheckCmd(),
+			NewTerraformCostDiffCmd(),
 			// State & Information Commands
+			NewTerraformOverviewCmd(),
 			NewTerraformStateListCmd(),
+			NewTerraformStateMvCmd(),
+			NewTerraformGenMovedCmd(),
+			NewTerraformGenImportCmd(),
 			NewTerraformOutputCmd(),
 			NewTerraformShowCmd(),
 			NewTerraformTestCmd(),
 			NewTerraformProviderCmd(),
 			NewTerraformWorkspaceCmd(),
 			NewTerraformGraphCmd(),
+			NewTerraformDepsCmd(),
 		},
-	}
+	})
 }
 
 // ============================================================================
@@ -96,8 +432,19 @@ func NewTerraformCmd() *ufcli.Command {
 // This is typically the first command run in a new Terraform project.
 func NewTerraformInitCmd() *ufcli.Command {
 	return &ufcli.Command{
-		Name:  "init",
-		Usage: "Initialize Terraform working directory",
+		Name:      "init",
+		Usage:     "Initialize Terraform working directory",
+		ArgsUsage: "[-- terraform args...]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.BoolFlag{
+				Name:  "check-providers",
+				Usage: "Check required_providers sources and versions before running init",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 			path := c.String("path")
@@ -105,10 +452,92 @@ func NewTerraformInitCmd() *ufcli.Command {
 			if err != nil {
 				return err
 			}
-			_, err = shell.Run(ctx, "terraform", "init", safePath)
+			if c.Bool("check-providers") {
+				if err := checkProviders(safePath); err != nil {
+					return err
+				}
+			}
+			extra, err := passThroughArgs(c)
+			if err != nil {
+				return err
+			}
+			args := append([]string{"init"}, extra...)
+			err = shell.RunInteractive(ctx, "terraform", chdirArgs(safePath, args...)...)
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+// defaultLockPlatforms are the platforms recorded in the lock file by upgrade-providers
+var defaultLockPlatforms = []string{"darwin_amd64", "darwin_arm64", "linux_amd64"}
+
+// NewTerraformUpgradeProvidersCmd creates the upgrade-providers command.
+// Runs `terraform init -upgrade` followed by `terraform providers lock` for a
+// set of platforms, then reports how each provider's locked version changed.
+// This pairs the two steps of a provider upgrade that are easy to forget.
+func NewTerraformUpgradeProvidersCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "upgrade-providers",
+		Usage: "Upgrade providers and regenerate the lock file for all platforms",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.StringSliceFlag{
+				Name:  "platform",
+				Usage: "Platform to lock (repeatable)",
+				Value: ufcli.NewStringSlice(defaultLockPlatforms...),
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+			safePath, err := validatePath(c.String("path"))
+			if err != nil {
+				return err
+			}
+			lockFile := filepath.Join(safePath, ".terraform.lock.hcl")
+
+			before, err := readLockedProviders(lockFile)
 			if err != nil {
 				return err
 			}
+
+			// Step 1: Upgrade providers within their version constraints
+			fmt.Println("Step 1: Running terraform init -upgrade...")
+			if output, err := shell.RunWithDir(ctx, safePath, "terraform", "init", "-upgrade"); err != nil {
+				return fmt.Errorf("init -upgrade failed: %w\n%s", err, output)
+			}
+
+			// Step 2: Record hashes for every platform in the lock file
+			platforms := c.StringSlice("platform")
+			fmt.Printf("Step 2: Locking providers for %s...\n", strings.Join(platforms, ", "))
+			args := []string{"providers", "lock"}
+			for _, platform := range platforms {
+				args = append(args, "-platform="+platform)
+			}
+			if output, err := shell.RunWithDir(ctx, safePath, "terraform", args...); err != nil {
+				return fmt.Errorf("providers lock failed: %w\n%s", err, output)
+			}
+
+			after, err := readLockedProviders(lockFile)
+			if err != nil {
+				return err
+			}
+
+			// Step 3: Report version changes
+			fmt.Printf("\n%-50s %-15s %-15s\n", "Provider", "Before", "After")
+			fmt.Println(strings.Repeat("-", 80))
+			for _, provider := range sortedKeys(after) {
+				old := before[provider]
+				if old == "" {
+					old = "-"
+				}
+				fmt.Printf("%-50s %-15s %-15s\n", provider, old, after[provider])
+			}
 			return nil
 		},
 	}
@@ -116,22 +545,142 @@ func NewTerraformInitCmd() *ufcli.Command {
 
 // NewTerraformFormatCmd creates the fmt command.
 // Formats Terraform configuration files to a canonical format and style.
-// This ensures consistent code style across the project.
+// This ensures consistent code style across the project. With --changed only
+// the .tf files changed versus the base branch are formatted, and --check
+// verifies formatting without rewriting anything.
 func NewTerraformFormatCmd() *ufcli.Command {
 	return &ufcli.Command{
-		Name:  "fmt",
-		Usage: "Format Terraform configuration files",
+		Name:      "fmt",
+		Usage:     "Format Terraform configuration files",
+		ArgsUsage: "[-- terraform args...]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.BoolFlag{
+				Name:  "changed",
+				Usage: "Only format .tf files changed versus the base branch, including staged, unstaged, and untracked ones",
+			},
+			&ufcli.BoolFlag{
+				Name:  "check",
+				Usage: "Check formatting without modifying files",
+			},
+			&ufcli.BoolFlag{
+				Name:  "stage",
+				Usage: "git add the files that were reformatted; with --changed, format only staged files (for pre-commit hooks)",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
-			path := c.String("path")
-			safePath, err := validatePath(path)
+			check := c.Bool("check")
+			stage := c.Bool("stage")
+			if stage {
+				if check {
+					return fmt.Errorf("--stage and --check cannot be used together")
+				}
+				// Check if we're in a git repo
+				if _, err := shell.Run(ctx, "git", "rev-parse", "--git-dir"); err != nil {
+					return fmt.Errorf("not in a git repository")
+				}
+			}
+
+			if !c.Bool("changed") {
+				path := c.String("path")
+				safePath, err := validatePath(path)
+				if err != nil {
+					return err
+				}
+				args := []string{"fmt"}
+				if check {
+					args = append(args, "-check")
+				}
+				extra, err := passThroughArgs(c)
+				if err != nil {
+					return err
+				}
+				args = append(args, extra...)
+				output, err := shell.Run(ctx, "terraform", chdirArgs(safePath, args...)...)
+				if err != nil {
+					if check && output != "" {
+						return fmt.Errorf("files need formatting:\n%s", output)
+					}
+					return err
+				}
+				if stage {
+					// terraform fmt lists the files it rewrote, relative to safePath
+					var reformatted []string
+					for _, file := range strings.Split(output, "\n") {
+						if file = strings.TrimSpace(file); file != "" {
+							reformatted = append(reformatted, filepath.Join(safePath, file))
+						}
+					}
+					return stageFiles(ctx, reformatted)
+				}
+				return nil
+			}
+
+			// --path limits the changed files to that directory
+			safePath, err := validatePath(c.String("path"))
 			if err != nil {
 				return err
 			}
-			_, err = shell.Run(ctx, "terraform", "fmt", safePath)
+			var tfFiles []string
+			if stage {
+				tfFiles, err = getStagedTerraformFiles(ctx, safePath)
+			} else {
+				tfFiles, err = getLocalTerraformChanges(ctx, safePath)
+			}
 			if err != nil {
 				return err
 			}
+			if len(tfFiles) == 0 {
+				fmt.Println("No Terraform files changed")
+				return nil
+			}
+
+			// Format (or check) each changed file individually
+			var unformatted, reformatted []string
+			processed := 0
+			for _, file := range tfFiles {
+				// A file can be in the diff yet gone from disk, e.g. deleted
+				// after being committed; it isn't counted as checked
+				if _, err := os.Stat(file); err != nil {
+					continue
+				}
+				processed++
+				if check {
+					if _, err := shell.Run(ctx, "terraform", "fmt", "-check", file); err != nil {
+						// Only a non-zero exit means unformatted; anything
+						// else, e.g. terraform not installed, is a real failure
+						if shell.ExitCode(err) < 0 {
+							return fmt.Errorf("failed to check %s: %w", file, err)
+						}
+						unformatted = append(unformatted, file)
+					}
+					continue
+				}
+				output, err := shell.Run(ctx, "terraform", "fmt", file)
+				if err != nil {
+					return fmt.Errorf("failed to format %s: %w", file, err)
+				}
+				// terraform fmt prints the file name only if it rewrote it
+				if output != "" {
+					reformatted = append(reformatted, file)
+				}
+			}
+
+			if len(unformatted) > 0 {
+				return fmt.Errorf("files need formatting:\n  %s", strings.Join(unformatted, "\n  "))
+			}
+			if check {
+				fmt.Printf("✓ %d changed file(s) are formatted\n", processed)
+			} else {
+				fmt.Printf("✓ Formatted %d changed file(s)\n", processed)
+			}
+			if stage {
+				return stageFiles(ctx, reformatted)
+			}
 			return nil
 		},
 	}
@@ -142,8 +691,19 @@ func NewTerraformFormatCmd() *ufcli.Command {
 // Does not check against external APIs or verify resource existence.
 func NewTerraformValidateCmd() *ufcli.Command {
 	return &ufcli.Command{
-		Name:  "validate",
-		Usage: "Validate Terraform configuration syntax",
+		Name:      "validate",
+		Usage:     "Validate Terraform configuration syntax",
+		ArgsUsage: "[-- terraform args...]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.BoolFlag{
+				Name:  "all-workspaces",
+				Usage: "Validate against every workspace, restoring the current one afterwards",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 			path := c.String("path")
@@ -151,7 +711,15 @@ func NewTerraformValidateCmd() *ufcli.Command {
 			if err != nil {
 				return err
 			}
-			_, err = shell.Run(ctx, "terraform", "validate", safePath)
+			if c.Bool("all-workspaces") {
+				return validateAllWorkspaces(ctx, safePath)
+			}
+			extra, err := passThroughArgs(c)
+			if err != nil {
+				return err
+			}
+			args := append([]string{"validate"}, extra...)
+			err = shell.RunInteractive(ctx, "terraform", chdirArgs(safePath, args...)...)
 			if err != nil {
 				return err
 			}
@@ -160,13 +728,108 @@ func NewTerraformValidateCmd() *ufcli.Command {
 	}
 }
 
+// validateAllWorkspaces selects each workspace in turn and validates it,
+// restoring the originally selected workspace even if validation fails
+func validateAllWorkspaces(ctx context.Context, safePath string) (err error) {
+	workspaces, current, err := listWorkspaces(ctx, safePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if _, restoreErr := shell.Run(ctx, "terraform", chdirArgs(safePath, "workspace", "select", current)...); restoreErr != nil {
+			fmt.Printf("⚠ Failed to restore workspace '%s': %v\n", current, restoreErr)
+			if err == nil {
+				err = fmt.Errorf("failed to restore workspace %s: %w", current, restoreErr)
+			}
+		}
+	}()
+
+	var failed []string
+	for _, workspace := range workspaces {
+		if _, err := shell.Run(ctx, "terraform", chdirArgs(safePath, "workspace", "select", workspace)...); err != nil {
+			fmt.Printf("✗ %s: failed to select workspace: %v\n", workspace, err)
+			failed = append(failed, workspace)
+			continue
+		}
+		if output, err := shell.Run(ctx, "terraform", chdirArgs(safePath, "validate")...); err != nil {
+			fmt.Printf("✗ %s\n%s\n", workspace, output)
+			failed = append(failed, workspace)
+			continue
+		}
+		fmt.Printf("✓ %s\n", workspace)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("validation failed in %d of %d workspace(s): %s", len(failed), len(workspaces), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// listWorkspaces returns all workspaces and the currently selected one
+func listWorkspaces(ctx context.Context, dir string) ([]string, string, error) {
+	output, err := shell.Run(ctx, "terraform", chdirArgs(dir, "workspace", "list")...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var workspaces []string
+	current := ""
+	for _, line := range strings.Split(output, "\n") {
+		name := strings.TrimSpace(line)
+		if strings.HasPrefix(name, "*") {
+			name = strings.TrimSpace(strings.TrimPrefix(name, "*"))
+			current = name
+		}
+		if name != "" {
+			workspaces = append(workspaces, name)
+		}
+	}
+
+	if current == "" {
+		return nil, "", fmt.Errorf("could not determine the current workspace")
+	}
+	return workspaces, current, nil
+}
+
 // NewTerraformPlanCmd creates the plan command.
 // Creates an execution plan showing what actions Terraform will take to reach
 // the desired state. This is a dry-run that doesn't make any changes.
 func NewTerraformPlanCmd() *ufcli.Command {
 	return &ufcli.Command{
-		Name:  "plan",
-		Usage: "Generate and show an execution plan",
+		Name:      "plan",
+		Usage:     "Generate and show an execution plan",
+		ArgsUsage: "[-- terraform args...]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.StringFlag{
+				Name:  "out",
+				Usage: "Save the plan to a file (can be approved with 'approve')",
+			},
+			&ufcli.StringFlag{
+				Name:  "on-changes",
+				Usage: "Shell command to run when the plan shows changes (summary in $CC_PLAN_SUMMARY)",
+			},
+			&ufcli.BoolFlag{
+				Name:  "changed-only",
+				Usage: "Target only the resources declared in changed .tf files (partial plan)",
+			},
+			&ufcli.StringFlag{
+				Name:  "save-plan-text",
+				Usage: "Also write the human-readable plan, without color codes, to this file",
+			},
+			&ufcli.BoolFlag{
+				Name:  "highlight-destroys",
+				Usage: "List resources the plan destroys and exit with code 3 unless --allow-destroy is set",
+			},
+			&ufcli.BoolFlag{
+				Name:  "allow-destroy",
+				Usage: "Accept plans that destroy resources",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 			path := c.String("path")
@@ -174,22 +837,226 @@ func NewTerraformPlanCmd() *ufcli.Command {
 			if err != nil {
 				return err
 			}
-			_, err = shell.Run(ctx, "terraform", "plan", safePath)
+			args := []string{"plan"}
+			if out := c.String("out"); out != "" {
+				// -chdir would otherwise resolve the plan file from safePath
+				absOut, err := filepath.Abs(out)
+				if err != nil {
+					return fmt.Errorf("invalid --out path: %w", err)
+				}
+				args = append(args, "-out="+absOut)
+			}
+
+			if c.Bool("changed-only") {
+				targets, err := getChangedTargets(ctx, safePath)
+				if err != nil {
+					return err
+				}
+				if len(targets) == 0 {
+					fmt.Println("No changed resources to plan")
+					return nil
+				}
+				fmt.Println("⚠ Planning only resources in changed files. This is a partial plan:")
+				for _, target := range targets {
+					fmt.Printf("  - %s\n", target)
+					args = append(args, "-target="+target)
+				}
+			}
+
+			extra, err := passThroughArgs(c)
 			if err != nil {
 				return err
 			}
-			return nil
+			args = append(args, extra...)
+
+			onChanges := c.String("on-changes")
+			saveText := c.String("save-plan-text")
+			highlight := c.Bool("highlight-destroys")
+			if onChanges == "" && saveText == "" && !highlight {
+				err = shell.RunInteractive(ctx, "terraform", chdirArgs(safePath, args...)...)
+				if err != nil {
+					return err
+				}
+				return nil
+			}
+
+			// With -detailed-exitcode, exit code 2 means the plan succeeded with changes
+			if onChanges != "" {
+				args = append(args, "-detailed-exitcode")
+			}
+
+			// Tee so the plan streams while its output is kept for the checks below
+			output, err := shell.RunTee(ctx, "terraform", chdirArgs(safePath, args...)...)
+
+			if saveText != "" && shell.IsDryRun(ctx) {
+				fmt.Printf("Would save plan text to %s\n", saveText)
+			} else if saveText != "" {
+				if writeErr := os.WriteFile(saveText, []byte(stripANSI(output)+"\n"), 0644); writeErr != nil {
+					return fmt.Errorf("failed to save plan text: %w", writeErr)
+				}
+				fmt.Printf("Plan text saved to %s\n", saveText)
+			}
+
+			// Destroys are reported after the normal output so they can't be missed
+			var destroyErr error
+			if highlight {
+				destroys := parsePlanDestroys(output)
+				printDestroyWarning(destroys)
+				if len(destroys) > 0 && !c.Bool("allow-destroy") {
+					destroyErr = ufcli.Exit(fmt.Sprintf("plan destroys %d resource(s); re-run with --allow-destroy to accept", len(destroys)), destroyExitCode)
+				}
+			}
+
+			if onChanges == "" {
+				if err != nil {
+					return err
+				}
+				return destroyErr
+			}
+
+			switch shell.ExitCode(err) {
+			case 0:
+				fmt.Println("No changes detected")
+				return nil
+			case 2:
+				summary := parsePlanSummary(output)
+				if summary == "" {
+					summary = "Plan has changes"
+				}
+				if err := runHook(ctx, onChanges, "CC_PLAN_SUMMARY="+summary); err != nil {
+					return fmt.Errorf("on-changes hook failed: %w", err)
+				}
+				return destroyErr
+			default:
+				return err
+			}
 		},
 	}
 }
 
+// destroyExitCode is returned by plan --highlight-destroys when the plan
+// destroys resources and --allow-destroy was not given
+const destroyExitCode = 3
+
+// planDestroyPattern matches resources the plan text marks for destruction,
+// including replacements, which destroy the existing object
+var planDestroyPattern = regexp.MustCompile(`(?m)^\s*# (\S+) (will be destroyed|must be replaced)`)
+
+// parsePlanDestroys returns the addresses a plan destroys or replaces,
+// with replacements annotated
+func parsePlanDestroys(output string) []string {
+	var destroys []string
+	for _, match := range planDestroyPattern.FindAllStringSubmatch(stripANSI(output), -1) {
+		if match[2] == "must be replaced" {
+			destroys = append(destroys, match[1]+" (replace)")
+		} else {
+			destroys = append(destroys, match[1])
+		}
+	}
+	return destroys
+}
+
+// printDestroyWarning prints a prominent list of resources that will be destroyed
+func printDestroyWarning(destroys []string) {
+	if len(destroys) == 0 {
+		fmt.Println("\n✓ No resources will be destroyed")
+		return
+	}
+
+	banner := strings.Repeat("!", 80)
+	fmt.Println("\n" + banner)
+	fmt.Printf("⚠️  DESTRUCTIVE CHANGES: %d resource(s) will be destroyed\n", len(destroys))
+	fmt.Println(banner)
+	for _, address := range destroys {
+		fmt.Printf("  - %s\n", address)
+	}
+	fmt.Println(banner)
+}
+
+// planFileChanges is the subset of `terraform show -json <plan>` read by apply
+type planFileChanges struct {
+	ResourceChanges []struct {
+		Address string `json:"address"`
+		Change  struct {
+			Actions []string `json:"actions"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// readPlanFileDestroys returns the addresses a saved plan deletes
+func readPlanFileDestroys(ctx context.Context, dir, planFile string) ([]string, error) {
+	output, err := shell.Run(ctx, "terraform", chdirArgs(dir, "show", "-json", planFile)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan %s: %w", planFile, err)
+	}
+
+	var plan planFileChanges
+	if err := json.Unmarshal([]byte(output), &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan %s: %w", planFile, err)
+	}
+
+	var destroys []string
+	for _, rc := range plan.ResourceChanges {
+		if !slices.Contains(rc.Change.Actions, "delete") {
+			continue
+		}
+		if slices.Contains(rc.Change.Actions, "create") {
+			destroys = append(destroys, rc.Address+" (replace)")
+		} else {
+			destroys = append(destroys, rc.Address)
+		}
+	}
+	return destroys, nil
+}
+
 // NewTerraformApplyCmd creates the apply command.
 // Applies the changes required to reach the desired state of the configuration.
 // This command modifies real infrastructure and should be used with caution.
+// Optional pre/post hooks run shell commands around the apply, e.g. fetching
+// secrets beforehand or sending a notification afterwards.
 func NewTerraformApplyCmd() *ufcli.Command {
 	return &ufcli.Command{
-		Name:  "apply",
-		Usage: "Apply Terraform changes to infrastructure",
+		Name:      "apply",
+		Usage:     "Apply Terraform changes to infrastructure",
+		ArgsUsage: "[-- terraform args...]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.StringFlag{
+				Name:  "pre-hook",
+				Usage: "Shell command to run before apply (apply is aborted if it fails)",
+			},
+			&ufcli.StringFlag{
+				Name:  "post-hook",
+				Usage: "Shell command to run after a successful apply",
+			},
+			&ufcli.BoolFlag{
+				Name:  "post-hook-always",
+				Usage: "Run the post-hook even if apply fails",
+			},
+			&ufcli.StringFlag{
+				Name:  "plan",
+				Usage: "Apply a saved plan file created with 'plan --out'",
+			},
+			&ufcli.StringFlag{
+				Name:  "require-approval-file",
+				Usage: "Refuse to apply unless this file holds the approval token for --plan",
+			},
+			&ufcli.StringFlag{
+				Name:  "audit-log",
+				Usage: "Append a JSON record of a successful apply to this file",
+			},
+			&ufcli.BoolFlag{
+				Name:  "allow-destroy",
+				Usage: "Allow applying a saved --plan that destroys resources",
+			},
+			&ufcli.BoolFlag{
+				Name:  "auto-approve",
+				Usage: "Skip terraform's interactive approval prompt (for CI)",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 			path := c.String("path")
@@ -197,10 +1064,203 @@ func NewTerraformApplyCmd() *ufcli.Command {
 			if err != nil {
 				return err
 			}
-			_, err = shell.Run(ctx, "terraform", "apply", safePath)
+			extra, err := passThroughArgs(c)
 			if err != nil {
 				return err
 			}
+
+			// Verify the saved plan matches the approved one before anything runs
+			planFile := c.String("plan")
+			if planFile != "" {
+				// -chdir would otherwise resolve the plan file from safePath
+				if planFile, err = filepath.Abs(planFile); err != nil {
+					return fmt.Errorf("invalid --plan path: %w", err)
+				}
+			}
+			// Saved plans apply without a prompt
+			if planFile == "" {
+				if err := requireApproval(c, "apply"); err != nil {
+					return err
+				}
+			}
+			if approvalFile := c.String("require-approval-file"); approvalFile != "" {
+				if planFile == "" {
+					return fmt.Errorf("--require-approval-file requires --plan")
+				}
+				if err := verifyApproval(planFile, approvalFile); err != nil {
+					return err
+				}
+				fmt.Println("✓ Plan approval verified")
+			}
+
+			// A saved plan can be inspected up front; refuse destroys unless allowed
+			if planFile != "" {
+				destroys, err := readPlanFileDestroys(ctx, safePath, planFile)
+				if err != nil {
+					return err
+				}
+				if len(destroys) > 0 {
+					printDestroyWarning(destroys)
+					if !c.Bool("allow-destroy") {
+						return ufcli.Exit(fmt.Sprintf("plan destroys %d resource(s); re-run with --allow-destroy to apply it", len(destroys)), destroyExitCode)
+					}
+				}
+			}
+
+			// Step 1: Run the pre-hook, aborting on failure
+			if preHook := c.String("pre-hook"); preHook != "" {
+				if err := runHook(ctx, preHook); err != nil {
+					return fmt.Errorf("pre-hook failed, apply aborted: %w", err)
+				}
+			}
+
+			// Step 2: Apply the saved plan, or the configuration at the path
+			args := []string{"apply"}
+			if c.Bool("auto-approve") {
+				args = append(args, "-auto-approve")
+			}
+			args = append(args, extra...)
+			if planFile != "" {
+				args = append(args, planFile)
+			}
+			args = chdirArgs(safePath, args...)
+			auditLog := c.String("audit-log")
+			var applyOutput string
+			var applyErr error
+			if auditLog != "" {
+				applyOutput, applyErr = shell.RunTee(ctx, "terraform", args...)
+			} else {
+				applyErr = shell.RunInteractive(ctx, "terraform", args...)
+			}
+
+			// A failed audit write is only a warning: the apply already
+			// happened, and the post-hook should still see it
+			if auditLog != "" && applyErr == nil {
+				if err := writeAuditRecord(ctx, auditLog, safePath, applyOutput); err != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+				} else {
+					fmt.Printf("✓ Apply recorded in %s\n", auditLog)
+				}
+			}
+
+			// Step 3: Run the post-hook on success, or always if requested
+			if postHook := c.String("post-hook"); postHook != "" && (applyErr == nil || c.Bool("post-hook-always")) {
+				if err := runHook(ctx, postHook); err != nil {
+					if applyErr != nil {
+						return fmt.Errorf("apply failed: %w (post-hook also failed: %v)", applyErr, err)
+					}
+					return fmt.Errorf("post-hook failed: %w", err)
+				}
+			}
+
+			return applyErr
+		},
+	}
+}
+
+// applySummaryPattern matches the resource counts terraform prints after an apply
+var applySummaryPattern = regexp.MustCompile(`Resources: (\d+) added, (\d+) changed, (\d+) destroyed`)
+
+// auditRecord is one line of the --audit-log file
+type auditRecord struct {
+	Timestamp string `json:"timestamp"`
+	Directory string `json:"directory"`
+	Workspace string `json:"workspace"`
+	User      string `json:"user"`
+	Created   int    `json:"created"`
+	Updated   int    `json:"updated"`
+	Deleted   int    `json:"deleted"`
+}
+
+// writeAuditRecord appends a JSON record of an apply to the audit log
+func writeAuditRecord(ctx context.Context, auditLog, dir, applyOutput string) error {
+	record := auditRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Directory: dir,
+	}
+
+	if match := applySummaryPattern.FindStringSubmatch(stripANSI(applyOutput)); match != nil {
+		record.Created, _ = strconv.Atoi(match[1])
+		record.Updated, _ = strconv.Atoi(match[2])
+		record.Deleted, _ = strconv.Atoi(match[3])
+	}
+
+	if workspace, err := shell.Run(ctx, "terraform", chdirArgs(dir, "workspace", "show")...); err == nil {
+		record.Workspace = workspace
+	}
+
+	name, _ := shell.Run(ctx, "git", "config", "user.name")
+	email, _ := shell.Run(ctx, "git", "config", "user.email")
+	switch {
+	case name != "" && email != "":
+		record.User = fmt.Sprintf("%s <%s>", name, email)
+	case name != "":
+		record.User = name
+	default:
+		record.User = email
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+
+	if shell.IsDryRun(ctx) {
+		fmt.Printf("Would append an audit record to %s\n", auditLog)
+		return nil
+	}
+	f, err := os.OpenFile(auditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// NewTerraformApproveCmd creates the approve command.
+// Records approval of a saved plan by writing a token derived from the plan
+// file's hash. 'apply --require-approval-file' checks the token so that the
+// exact plan that was reviewed is the one that gets applied.
+func NewTerraformApproveCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "approve",
+		Usage:     "Approve a saved plan file for apply --require-approval-file",
+		ArgsUsage: "<plan-file>",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "approval-file",
+				Usage: "Where to write the approval token (default: <plan-file>.approval)",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 1 {
+				return fmt.Errorf("plan file is required")
+			}
+			planFile := c.Args().First()
+
+			token, err := planApprovalToken(planFile)
+			if err != nil {
+				return err
+			}
+
+			approvalFile := c.String("approval-file")
+			if approvalFile == "" {
+				approvalFile = planFile + ".approval"
+			}
+			if shell.IsDryRun(c.Context) {
+				fmt.Printf("Would write approval %s to %s\n", token, approvalFile)
+				return nil
+			}
+			if err := os.WriteFile(approvalFile, []byte(token+"\n"), 0644); err != nil {
+				return fmt.Errorf("failed to write approval file: %w", err)
+			}
+
+			fmt.Printf("✓ Approved %s (%s)\n", planFile, token)
+			fmt.Printf("Approval written to %s\n", approvalFile)
 			return nil
 		},
 	}
@@ -208,11 +1268,27 @@ func NewTerraformApplyCmd() *ufcli.Command {
 
 // NewTerraformDestroyCmd creates the destroy command.
 // Destroys all resources managed by the Terraform configuration.
-// This is a destructive operation that permanently removes infrastructure.
+// This is a destructive operation that permanently removes infrastructure, so
+// by default a destroy plan is previewed and confirmed before anything is removed.
 func NewTerraformDestroyCmd() *ufcli.Command {
 	return &ufcli.Command{
-		Name:  "destroy",
-		Usage: "Destroy Terraform-managed infrastructure",
+		Name:      "destroy",
+		Usage:     "Preview, confirm, and destroy Terraform-managed infrastructure",
+		ArgsUsage: "[-- terraform args...]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.BoolFlag{
+				Name:  "auto-approve",
+				Usage: "Skip the confirmation prompts (for CI)",
+			},
+			&ufcli.BoolFlag{
+				Name:  "skip-plan",
+				Usage: "Run terraform destroy directly, without the plan -destroy preview",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 			path := c.String("path")
@@ -220,13 +1296,81 @@ func NewTerraformDestroyCmd() *ufcli.Command {
 			if err != nil {
 				return err
 			}
-			_, err = shell.Run(ctx, "terraform", "destroy", safePath)
+			// Destroy is never run unattended without an explicit --auto-approve
+			if err := requireApproval(c, "destroy"); err != nil {
+				return err
+			}
+
+			if c.Bool("skip-plan") {
+				args := []string{"destroy"}
+				if c.Bool("auto-approve") {
+					args = append(args, "-auto-approve")
+				}
+				extra, err := passThroughArgs(c)
+				if err != nil {
+					return err
+				}
+				args = append(args, extra...)
+				return shell.RunInteractive(ctx, "terraform", chdirArgs(safePath, args...)...)
+			}
+			return destroyWithPreview(c, safePath)
+		},
+	}
+}
+
+// destroyWithPreview saves a destroy plan, lists what it removes, asks for
+// the workspace name to be typed, and then applies exactly that plan
+func destroyWithPreview(c *ufcli.Context, dir string) error {
+	ctx := c.Context
+
+	planFile, err := os.CreateTemp("", "cc-destroy-*.tfplan")
+	if err != nil {
+		return fmt.Errorf("failed to create plan file: %w", err)
+	}
+	planFile.Close()
+	defer os.Remove(planFile.Name())
+
+	// Step 1: Plan the destroy; forwarded args such as -var-file apply here
+	fmt.Println("Planning destroy...")
+	extra, err := passThroughArgs(c)
+	if err != nil {
+		return err
+	}
+	args := append([]string{"plan", "-destroy", "-out=" + planFile.Name()}, extra...)
+	if err := shell.RunInteractive(ctx, "terraform", chdirArgs(dir, args...)...); err != nil {
+		return fmt.Errorf("destroy plan failed: %w", err)
+	}
+
+	// Step 2: Show what will be destroyed and confirm
+	if !shell.IsDryRun(ctx) {
+		destroys, err := readPlanFileDestroys(ctx, dir, planFile.Name())
+		if err != nil {
+			return err
+		}
+		if len(destroys) == 0 {
+			fmt.Println("\n✓ Nothing to destroy")
+			return nil
+		}
+		printDestroyWarning(destroys)
+
+		if !c.Bool("auto-approve") {
+			_, workspace, err := listWorkspaces(ctx, dir)
 			if err != nil {
 				return err
 			}
-			return nil
-		},
+			confirmed, err := prompt.ConfirmExact(fmt.Sprintf("Destroy %d resource(s) in workspace '%s'?", len(destroys), workspace), workspace)
+			if err != nil {
+				return fmt.Errorf("error reading input: %w", err)
+			}
+			if !confirmed {
+				fmt.Println("Destroy cancelled")
+				return nil
+			}
+		}
 	}
+
+	// Step 3: Apply the reviewed plan, so nothing beyond it is destroyed
+	return shell.RunInteractive(ctx, "terraform", chdirArgs(dir, "apply", planFile.Name())...)
 }
 
 // ============================================================================
@@ -249,53 +1393,44 @@ func NewTerraformScanCmd() *ufcli.Command {
 				Usage:   "Security tool to use: tfsec or tflint",
 				Value:   "tfsec",
 			},
+			&ufcli.StringFlag{
+				Name:  "base",
+				Usage: "Scan files changed since the branch diverged from this ref",
+				Value: defaultDiffBase,
+			},
 		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
-			tool := c.String("tool")
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			tool := cfg.Resolve("scan_tool", c, "tool").Value
 			// Validate tool flag to prevent command injection
 			if tool != "tfsec" && tool != "tflint" {
 				return fmt.Errorf("tool must be either 'tfsec' or 'tflint', got %s", tool)
 			}
 
-			// Step 1: Get changed files from git
-			// Attempts to get files changed between HEAD and origin/main
-			output, err := shell.Run(ctx, "git", "diff", "--name-only", "HEAD", "origin/main")
-			if err != nil {
-				// Fallback: try comparing with HEAD~1 (previous commit) if origin/main doesn't exist
-				output, err = shell.Run(ctx, "git", "diff", "--name-only", "HEAD~1", "HEAD")
-				if err != nil {
-					return fmt.Errorf("failed to get changed files: %w", err)
-				}
+			// Step 1: Get .tf files changed on this branch from git. Only an
+			// explicit --base is strict; the default may fall back to HEAD~1.
+			var base string
+			if c.IsSet("base") {
+				base = c.String("base")
 			}
-
-			// Step 2: Parse the output - split by newlines to get individual files
-			changedFiles := strings.Split(output, "\n")
-
-			// Step 3: Filter for only .tf files
-			var tfFiles []string
-			for _, file := range changedFiles {
-				file = strings.TrimSpace(file)
-				if file == "" {
-					continue // Skip empty lines
-				}
-				if strings.HasSuffix(file, ".tf") {
-					tfFiles = append(tfFiles, file)
-				}
+			tfFiles, err := getChangedTerraformFiles(ctx, base)
+			if err != nil {
+				return err
 			}
 
-			// Step 4: If no .tf files changed, exit early
+			// Step 2: If no .tf files changed, exit early
 			if len(tfFiles) == 0 {
 				fmt.Println("No Terraform files changed")
 				return nil
 			}
 
-			// Step 5: Run the tool on the changed .tf files
-			// Join the files with spaces to pass as arguments
-			filesArg := strings.Join(tfFiles, " ")
-
-			// Run the tool (tfsec or tflint) on the files
-			_, err = shell.Run(ctx, tool, filesArg)
+			// Step 3: Run the tool (tfsec or tflint) on the changed .tf files,
+			// passing each file as its own argument
+			_, err = shell.Run(ctx, tool, tfFiles...)
 			if err != nil {
 				return fmt.Errorf("scan failed: %w", err)
 			}
@@ -314,6 +1449,12 @@ func NewTerraformCheckCmd() *ufcli.Command {
 	return &ufcli.Command{
 		Name:  "check",
 		Usage: "Run fmt, validate, and security scans (pre-push workflow)",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "summary-only",
+				Usage: "Hide each step's output and print a pass/fail table, showing output only for failed steps",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 			path := c.String("path")
@@ -322,35 +1463,172 @@ func NewTerraformCheckCmd() *ufcli.Command {
 				return err
 			}
 
-			// Step 1: Format files
-			_, err = shell.Run(ctx, "terraform", "fmt", safePath)
-			if err != nil {
-				return err
+			steps := checkSteps(safePath)
+			if c.Bool("summary-only") {
+				return runCheckSummary(ctx, steps)
 			}
 
-			// Step 2: Validate syntax
-			_, err = shell.Run(ctx, "terraform", "validate", safePath)
-			if err != nil {
-				return err
+			for _, step := range steps {
+				fmt.Printf("==> %s (%s)\n", step.Name, step.Command)
+				if err := shell.RunInteractive(ctx, step.Command, step.Args...); err != nil {
+					return fmt.Errorf("%s failed: %w", step.Name, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// checkStep is one tool run by the check command
+type checkStep struct {
+	Name    string
+	Command string
+	Args    []string
+}
+
+// checkSteps lists the check command's steps for the module at path
+func checkSteps(path string) []checkStep {
+	return []checkStep{
+		// Format files
+		{Name: "fmt", Command: "terraform", Args: chdirArgs(path, "fmt")},
+		// Validate syntax
+		{Name: "validate", Command: "terraform", Args: chdirArgs(path, "validate")},
+		// Code quality linter
+		{Name: "lint", Command: "tflint", Args: []string{path}},
+		// Security scanner
+		{Name: "scan", Command: "tfsec", Args: []string{path}},
+	}
+}
+
+// runCheckSummary runs every step with its output captured, prints a
+// pass/fail table, and then the output of each failed step
+func runCheckSummary(ctx context.Context, steps []checkStep) error {
+	type result struct {
+		step   checkStep
+		output string
+		err    error
+	}
+
+	var results []result
+	var failed []string
+	for _, step := range steps {
+		output, err := shell.Run(ctx, step.Command, step.Args...)
+		results = append(results, result{step: step, output: output, err: err})
+		if err != nil {
+			failed = append(failed, step.Name)
+		}
+	}
+
+	fmt.Println("Check summary:")
+	for _, r := range results {
+		mark := "✓"
+		if r.err != nil {
+			mark = "✗"
+		}
+		fmt.Printf("  %s %-9s %s\n", mark, r.step.Name, r.step.Command)
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	for _, r := range results {
+		if r.err == nil {
+			continue
+		}
+		fmt.Printf("\n--- %s output ---\n", r.step.Name)
+		if r.output != "" {
+			fmt.Println(r.output)
+		}
+		fmt.Println(r.err)
+	}
+	return fmt.Errorf("%d of %d checks failed: %s", len(failed), len(steps), strings.Join(failed, ", "))
+}
+
+// infracostDiff is the subset of `infracost diff --format json` output cc reads
+type infracostDiff struct {
+	Currency             string  `json:"currency"`
+	TotalMonthlyCost     *string `json:"totalMonthlyCost"`
+	PastTotalMonthlyCost *string `json:"pastTotalMonthlyCost"`
+	DiffTotalMonthlyCost *string `json:"diffTotalMonthlyCost"`
+}
+
+// NewTerraformCostDiffCmd creates the cost-diff command.
+// Runs `infracost diff` against a saved baseline and summarizes the monthly
+// cost delta. With --threshold the command fails when the increase exceeds
+// the given dollar amount, so it can gate PRs on cost in CI.
+func NewTerraformCostDiffCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:  "cost-diff",
+		Usage: "Summarize the monthly cost change against a baseline (requires infracost)",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.StringFlag{
+				Name:  "baseline",
+				Usage: "Baseline produced by 'infracost breakdown --format json'",
+				Value: "infracost.json",
+			},
+			&ufcli.Float64Flag{
+				Name:  "threshold",
+				Usage: "Fail if the monthly cost increase exceeds this amount (0 disables)",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			ctx := c.Context
+			if !shell.CommandExists("infracost") {
+				return fmt.Errorf("infracost is not installed. Install it with: brew install infracost")
 			}
 
-			// Step 3: Run tflint (code quality linter)
-			_, err = shell.Run(ctx, "tflint", safePath)
+			safePath, err := validatePath(c.String("path"))
 			if err != nil {
 				return err
 			}
+			baseline := c.String("baseline")
+			if _, err := os.Stat(baseline); err != nil {
+				return fmt.Errorf("baseline %s not found. Create one on the base branch with: infracost breakdown --path . --format json --out-file %s", baseline, baseline)
+			}
 
-			// Step 4: Run tfsec (security scanner)
-			_, err = shell.Run(ctx, "tfsec", safePath)
+			output, err := shell.Run(ctx, "infracost", "diff", "--path", safePath, "--compare-to", baseline, "--format", "json")
 			if err != nil {
-				return err
+				return fmt.Errorf("infracost diff failed: %w\n%s", err, output)
+			}
+
+			var diff infracostDiff
+			if err := json.Unmarshal([]byte(output), &diff); err != nil {
+				return fmt.Errorf("failed to parse infracost output: %w", err)
 			}
 
+			past := parseCost(diff.PastTotalMonthlyCost)
+			total := parseCost(diff.TotalMonthlyCost)
+			delta := parseCost(diff.DiffTotalMonthlyCost)
+
+			fmt.Printf("Baseline monthly cost: %10.2f %s\n", past, diff.Currency)
+			fmt.Printf("Proposed monthly cost: %10.2f %s\n", total, diff.Currency)
+			fmt.Printf("Change:                %+10.2f %s\n", delta, diff.Currency)
+
+			if threshold := c.Float64("threshold"); threshold > 0 && delta > threshold {
+				return fmt.Errorf("monthly cost increase of %.2f %s exceeds threshold of %.2f", delta, diff.Currency, threshold)
+			}
 			return nil
 		},
 	}
 }
 
+// parseCost converts infracost's string-encoded cost to a number,
+// treating missing values as zero
+func parseCost(value *string) float64 {
+	if value == nil {
+		return 0
+	}
+	cost, err := strconv.ParseFloat(*value, 64)
+	if err != nil {
+		return 0
+	}
+	return cost
+}
+
 // ============================================================================
 // State & Information Commands
 // ============================================================================
@@ -373,15 +1651,263 @@ func NewTerraformStateListCmd() *ufcli.Command {
 	}
 }
 
+// NewTerraformStateMvCmd creates the state-mv command.
+// Moves a resource to a new address in the Terraform state. The source address
+// is verified against the state first, since a typo would otherwise silently
+// no-op or move the wrong thing. --dry-run prints the move without performing it.
+func NewTerraformStateMvCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "state-mv",
+		Usage:     "Move a resource to a new address in Terraform state",
+		ArgsUsage: "<source> <destination>",
+		Flags: []ufcli.Flag{
+			&ufcli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Verify the addresses and print the move without performing it",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 2 {
+				return fmt.Errorf("source and destination addresses are required")
+			}
+			source := c.Args().Get(0)
+			destination := c.Args().Get(1)
+			ctx := c.Context
+
+			// Step 1: Verify the source exists and the destination is free
+			output, err := shell.Run(ctx, "terraform", "state", "list")
+			if err != nil {
+				return fmt.Errorf("failed to list state: %w", err)
+			}
+			addresses := strings.Split(output, "\n")
+			if !stateHasAddress(addresses, source) {
+				return fmt.Errorf("source address %s not found in state", source)
+			}
+			if stateHasAddress(addresses, destination) {
+				return fmt.Errorf("destination address %s already exists in state", destination)
+			}
+
+			// Step 2: Show the planned move
+			fmt.Printf("Will move:\n  %s\n  -> %s\n", source, destination)
+			if c.Bool("dry-run") || shell.IsDryRun(ctx) {
+				fmt.Println("Dry run: no changes made")
+				return nil
+			}
+
+			// Step 3: Confirm and move
+			confirm, err := prompt.Confirm("Proceed with state move?")
+			if err != nil {
+				return fmt.Errorf("error reading input: %w", err)
+			}
+			if !confirm {
+				fmt.Println("State move cancelled")
+				return nil
+			}
+
+			if _, err := shell.Run(ctx, "terraform", "state", "mv", source, destination); err != nil {
+				return fmt.Errorf("state move failed: %w", err)
+			}
+			fmt.Printf("✓ Moved %s to %s\n", source, destination)
+			return nil
+		},
+	}
+}
+
+// stateHasAddress reports whether addr is in the state list, either exactly
+// or as a module or counted resource containing listed instances, e.g.
+// module.vpc for module.vpc.aws_vpc.main or aws_instance.web for aws_instance.web[0]
+func stateHasAddress(addresses []string, addr string) bool {
+	for _, a := range addresses {
+		a = strings.TrimSpace(a)
+		if a == addr || strings.HasPrefix(a, addr+".") || strings.HasPrefix(a, addr+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTerraformGenMovedCmd creates the gen-moved command.
+// Appends a moved block to moved.tf so that renaming a resource or module
+// doesn't destroy and recreate it. --dry-run prints the block instead.
+func NewTerraformGenMovedCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "gen-moved",
+		Usage:     "Append a moved block for a renamed resource to moved.tf",
+		ArgsUsage: "<old-address> <new-address>",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the block instead of writing it",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 2 {
+				return fmt.Errorf("old and new addresses are required")
+			}
+			from := c.Args().Get(0)
+			to := c.Args().Get(1)
+
+			if err := validateAddress(from); err != nil {
+				return err
+			}
+			if err := validateAddress(to); err != nil {
+				return err
+			}
+			if from == to {
+				return fmt.Errorf("old and new addresses are the same")
+			}
+
+			block := fmt.Sprintf("moved {\n  from = %s\n  to   = %s\n}\n", from, to)
+			if c.Bool("dry-run") || shell.IsDryRun(c.Context) {
+				fmt.Print(block)
+				return nil
+			}
+
+			safePath, err := validatePath(c.String("path"))
+			if err != nil {
+				return err
+			}
+			file, err := appendBlock(safePath, "moved.tf", block)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ Added moved block to %s\n", file)
+			return nil
+		},
+	}
+}
+
+// NewTerraformGenImportCmd creates the gen-import command.
+// Appends an import block (Terraform 1.5+) to imports.tf so that existing
+// infrastructure is brought under management through a reviewable plan
+// instead of an imperative `terraform import`. --dry-run prints the block instead.
+func NewTerraformGenImportCmd() *ufcli.Command {
+	return &ufcli.Command{
+		Name:      "gen-import",
+		Usage:     "Append an import block for an existing resource to imports.tf",
+		ArgsUsage: "<address> <id>",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the block instead of writing it",
+			},
+		},
+		Action: func(c *ufcli.Context) error {
+			if c.NArg() < 2 {
+				return fmt.Errorf("address and id are required")
+			}
+			address := c.Args().Get(0)
+			id := c.Args().Get(1)
+
+			if err := validateImportTarget(address); err != nil {
+				return err
+			}
+			if strings.TrimSpace(id) == "" {
+				return fmt.Errorf("import id must not be empty")
+			}
+			if strings.IndexFunc(id, unicode.IsControl) >= 0 {
+				return fmt.Errorf("import id must not contain control characters")
+			}
+
+			block := fmt.Sprintf("import {\n  to = %s\n  id = %s\n}\n", address, hclString(id))
+			if c.Bool("dry-run") || shell.IsDryRun(c.Context) {
+				fmt.Print(block)
+				return nil
+			}
+
+			safePath, err := validatePath(c.String("path"))
+			if err != nil {
+				return err
+			}
+
+			// A second import block for the same address is a terraform error
+			existing, err := os.ReadFile(filepath.Join(safePath, "imports.tf"))
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read imports.tf: %w", err)
+			}
+			toPattern := regexp.MustCompile(`(?m)^\s*to\s*=\s*` + regexp.QuoteMeta(address) + `\s*$`)
+			if toPattern.Match(existing) {
+				return fmt.Errorf("imports.tf already has an import block for %s", address)
+			}
+
+			file, err := appendBlock(safePath, "imports.tf", block)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ Added import block to %s\n", file)
+			fmt.Println("Run 'cc terraform plan' to preview the import")
+			return nil
+		},
+	}
+}
+
+// moduleAddressPattern matches addresses that end at a module call
+var moduleAddressPattern = regexp.MustCompile(`(^|\.)module\.[A-Za-z_][\w-]*(\[[^\]]+\])?$`)
+
+// validateImportTarget checks that address names a managed resource, which
+// is the only kind of address an import block can target
+func validateImportTarget(address string) error {
+	if err := validateAddress(address); err != nil {
+		return err
+	}
+
+	if moduleAddressPattern.MatchString(address) {
+		return fmt.Errorf("import target must be a resource, not a module: %s", address)
+	}
+	if strings.HasPrefix(address, "data.") || strings.Contains(address, ".data.") {
+		return fmt.Errorf("data sources cannot be imported: %s", address)
+	}
+	return nil
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences
+func hclString(s string) string {
+	s = strings.ReplaceAll(s, "${", "$${")
+	s = strings.ReplaceAll(s, "%{", "%%{")
+	return strconv.Quote(s)
+}
+
 // NewTerraformOutputCmd creates the output command.
 // Shows the values of output variables defined in the Terraform configuration.
 // Outputs are typically used to expose important values like resource IDs or endpoints.
 func NewTerraformOutputCmd() *ufcli.Command {
 	return &ufcli.Command{
-		Name:  "output",
-		Usage: "Show Terraform output values",
+		Name:      "output",
+		Usage:     "Show Terraform output values",
+		ArgsUsage: "[name]",
+		Flags: []ufcli.Flag{
+			&ufcli.DurationFlag{
+				Name:  "watch",
+				Usage: "Re-read a single named output at this interval (e.g. 10s) and print changes",
+			},
+			&ufcli.BoolFlag{
+				Name:  "github-output",
+				Usage: "Write outputs as name=value lines to the file in $GITHUB_OUTPUT (GitHub Actions)",
+			},
+			&ufcli.BoolFlag{
+				Name:  "include-sensitive",
+				Usage: "Also write sensitive outputs with --github-output, masking their values in the job log",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
+			if interval := c.Duration("watch"); interval > 0 {
+				if c.NArg() != 1 {
+					return fmt.Errorf("--watch requires exactly one output name")
+				}
+				return watchOutput(ctx, c.Args().First(), interval)
+			}
+			if c.Bool("github-output") {
+				return writeGitHubOutputs(ctx, c.Bool("include-sensitive"))
+			}
 			_, err := shell.Run(ctx, "terraform", "output")
 			if err != nil {
 				return err
@@ -391,13 +1917,55 @@ func NewTerraformOutputCmd() *ufcli.Command {
 	}
 }
 
+// watchOutput polls a named output and prints its value whenever it
+// changes, until interrupted with Ctrl-C or the context is cancelled
+func watchOutput(ctx context.Context, name string, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Watching output '%s' every %s (Ctrl-C to stop)...\n", name, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := ""
+	first := true
+	for {
+		value, err := shell.Run(ctx, "terraform", "output", "-raw", name)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			value = fmt.Sprintf("error: %s", value)
+		}
+		if first || value != last {
+			fmt.Printf("[%s] %s\n", time.Now().Format("15:04:05"), value)
+			last = value
+			first = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
 // NewTerraformShowCmd creates the show command.
 // Displays human-readable output from a state file or plan file.
 // Useful for inspecting the current or planned state of infrastructure.
 func NewTerraformShowCmd() *ufcli.Command {
 	return &ufcli.Command{
-		Name:  "show",
-		Usage: "Show Terraform state or plan in human-readable format",
+		Name:      "show",
+		Usage:     "Show Terraform state or plan in human-readable format",
+		ArgsUsage: "[-- terraform args...]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 			path := c.String("path")
@@ -405,7 +1973,12 @@ func NewTerraformShowCmd() *ufcli.Command {
 			if err != nil {
 				return err
 			}
-			_, err = shell.Run(ctx, "terraform", "show", safePath)
+			extra, err := passThroughArgs(c)
+			if err != nil {
+				return err
+			}
+			args := append([]string{"show"}, extra...)
+			err = shell.RunInteractive(ctx, "terraform", chdirArgs(safePath, args...)...)
 			if err != nil {
 				return err
 			}
@@ -419,8 +1992,27 @@ func NewTerraformShowCmd() *ufcli.Command {
 // Tests verify that Terraform configurations behave as expected.
 func NewTerraformTestCmd() *ufcli.Command {
 	return &ufcli.Command{
-		Name:  "test",
-		Usage: "Run Terraform tests",
+		Name:      "test",
+		Usage:     "Run Terraform tests",
+		ArgsUsage: "[-- terraform args...]",
+		Flags: []ufcli.Flag{
+			&ufcli.StringFlag{
+				Name:  "path",
+				Usage: "Directory containing the Terraform configuration",
+			},
+			&ufcli.StringSliceFlag{
+				Name:  "filter",
+				Usage: "Only run the given test file (repeatable), e.g. tests/s3.tftest.hcl",
+			},
+			&ufcli.BoolFlag{
+				Name:  "verbose",
+				Usage: "Print the plan or state for each run block",
+			},
+			&ufcli.BoolFlag{
+				Name:  "json",
+				Usage: "Parse terraform's JSON output into a pass/fail summary",
+			},
+		},
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
 			path := c.String("path")
@@ -428,15 +2020,136 @@ func NewTerraformTestCmd() *ufcli.Command {
 			if err != nil {
 				return err
 			}
-			_, err = shell.Run(ctx, "terraform", "test", safePath)
+
+			args := chdirArgs(safePath, "test")
+			for _, filter := range c.StringSlice("filter") {
+				args = append(args, "-filter="+filter)
+			}
+			if c.Bool("verbose") {
+				args = append(args, "-verbose")
+			}
+			extra, err := passThroughArgs(c)
 			if err != nil {
 				return err
 			}
+			args = append(args, extra...)
+
+			if !c.Bool("json") {
+				return shell.RunInteractive(ctx, "terraform", args...)
+			}
+
+			output, runErr := shell.Run(ctx, "terraform", append(args, "-json")...)
+			summary := parseTestResults(output)
+			printTestSummary(summary)
+
+			if summary.Failed > 0 || summary.Errored > 0 {
+				return fmt.Errorf("%d test run(s) failed", summary.Failed+summary.Errored)
+			}
+			if runErr != nil {
+				return fmt.Errorf("terraform test failed: %w", runErr)
+			}
 			return nil
 		},
 	}
 }
 
+// testMessage is one line of `terraform test -json` output
+type testMessage struct {
+	Type    string `json:"type"`
+	File    string `json:"@testfile"`
+	Run     string `json:"@testrun"`
+	TestRun *struct {
+		Progress string `json:"progress"`
+		Status   string `json:"status"`
+	} `json:"test_run"`
+	Diagnostic *struct {
+		Severity string `json:"severity"`
+		Summary  string `json:"summary"`
+		Detail   string `json:"detail"`
+	} `json:"diagnostic"`
+}
+
+// testFailure is a failed run along with its error diagnostics
+type testFailure struct {
+	Name     string
+	Messages []string
+}
+
+// testSummary tallies the results of a terraform test run
+type testSummary struct {
+	Run      int
+	Passed   int
+	Failed   int
+	Errored  int
+	Skipped  int
+	Failures []testFailure
+}
+
+// parseTestResults builds a summary from `terraform test -json` output.
+// Lines that are not JSON are ignored.
+func parseTestResults(output string) testSummary {
+	var summary testSummary
+	diagnostics := map[string][]string{}
+	var failed []string
+
+	for _, line := range strings.Split(output, "\n") {
+		var msg testMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			continue
+		}
+		name := msg.File
+		if msg.Run != "" {
+			name += "/" + msg.Run
+		}
+
+		switch msg.Type {
+		case "diagnostic":
+			if msg.Diagnostic != nil && msg.Diagnostic.Severity == "error" {
+				text := msg.Diagnostic.Summary
+				if msg.Diagnostic.Detail != "" {
+					text += ": " + msg.Diagnostic.Detail
+				}
+				diagnostics[name] = append(diagnostics[name], text)
+			}
+		case "test_run":
+			if msg.TestRun == nil || msg.TestRun.Progress != "complete" {
+				continue
+			}
+			summary.Run++
+			switch msg.TestRun.Status {
+			case "pass":
+				summary.Passed++
+			case "fail":
+				summary.Failed++
+				failed = append(failed, name)
+			case "error":
+				summary.Errored++
+				failed = append(failed, name)
+			default:
+				summary.Skipped++
+			}
+		}
+	}
+
+	for _, name := range failed {
+		summary.Failures = append(summary.Failures, testFailure{Name: name, Messages: diagnostics[name]})
+	}
+	return summary
+}
+
+// printTestSummary prints run counts followed by each failure's diagnostics
+func printTestSummary(summary testSummary) {
+	fmt.Printf("Tests run: %d, passed: %d, failed: %d, errored: %d, skipped: %d\n",
+		summary.Run, summary.Passed, summary.Failed, summary.Errored, summary.Skipped)
+
+	for _, failure := range summary.Failures {
+		fmt.Printf("\n✗ %s\n", failure.Name)
+		for _, message := range failure.Messages {
+			fmt.Printf("    %s\n", message)
+		}
+	}
+}
+
 // NewTerraformProviderCmd creates the providers command.
 // Lists all providers required by the current configuration.
 // Shows which providers Terraform will download during init.
@@ -461,18 +2174,82 @@ func NewTerraformProviderCmd() *ufcli.Command {
 func NewTerraformWorkspaceCmd() *ufcli.Command {
 	return &ufcli.Command{
 		Name:  "workspace",
-		Usage: "Manage Terraform workspaces",
+		Usage: "Select a Terraform workspace interactively (lists workspaces when not on a TTY)",
 		Action: func(c *ufcli.Context) error {
 			ctx := c.Context
-			_, err := shell.Run(ctx, "terraform", "workspace")
+			workspaces, current, err := listWorkspaces(ctx, ".")
+			if err != nil {
+				return err
+			}
+
+			if !shell.StdinIsTTY() || !shell.StdoutIsTTY() {
+				for _, ws := range workspaces {
+					marker := " "
+					if ws == current {
+						marker = "*"
+					}
+					fmt.Printf("%s %s\n", marker, ws)
+				}
+				return nil
+			}
+
+			fmt.Println("Terraform workspaces:")
+			for i, ws := range workspaces {
+				suffix := ""
+				if ws == current {
+					suffix = " (current)"
+				}
+				fmt.Printf("  %d) %s%s\n", i+1, ws, suffix)
+			}
+
+			choice, err := promptSelection(len(workspaces))
 			if err != nil {
 				return err
 			}
+			if choice < 0 {
+				fmt.Println("No workspace selected")
+				return nil
+			}
+
+			selected := workspaces[choice]
+			if selected == current {
+				fmt.Printf("Already on workspace %s\n", selected)
+				return nil
+			}
+
+			if _, err := shell.Run(ctx, "terraform", "workspace", "select", selected); err != nil {
+				return fmt.Errorf("failed to select workspace %s: %w", selected, err)
+			}
+			fmt.Printf("✓ Switched to workspace %s\n", selected)
 			return nil
 		},
 	}
 }
 
+// promptSelection asks for a 1-based choice from a numbered list and returns
+// its 0-based index, or -1 if the user enters nothing
+func promptSelection(count int) (int, error) {
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("Select a workspace [1-%d] (enter to cancel): ", count)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return -1, err
+		}
+
+		response = strings.TrimSpace(response)
+		if response == "" {
+			return -1, nil
+		}
+
+		choice, err := strconv.Atoi(response)
+		if err == nil && choice >= 1 && choice <= count {
+			return choice - 1, nil
+		}
+		fmt.Printf("Please enter a number between 1 and %d\n", count)
+	}
+}
+
 // NewTerraformGraphCmd creates the graph command.
 // Generates a visual representation of the Terraform dependency graph.
 // Output can be piped to GraphViz tools for visualization.
@@ -0,0 +1,265 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/christopher.carver/cc/internal/shell"
+	ufcli "github.com/urfave/cli/v2"
+)
+
+// requiredVersionPattern matches the required_version setting of a terraform block
+var requiredVersionPattern = regexp.MustCompile(`(?m)^\s*required_version\s*=\s*"([^"]*)"`)
+
+// versionPartsPattern splits a single constraint into its operator and version
+var versionPartsPattern = regexp.MustCompile(`^(=|!=|>=|<=|>|<|~>)?\s*v?(\d+(?:\.\d+){0,2})(?:-[0-9A-Za-z.-]+)?$`)
+
+// skipVersionCheck lists subcommands that never run terraform itself
+var skipVersionCheck = map[string]bool{
+	"approve":    true,
+	"scan":       true,
+	"cost-diff":  true,
+	"gen-moved":  true,
+	"gen-import": true,
+}
+
+// withVersionCheck adds --auto-switch to cmd's subcommands and checks the
+// installed terraform against the module's required_version before each runs
+func withVersionCheck(cmd *ufcli.Command) *ufcli.Command {
+	for _, sub := range cmd.Subcommands {
+		if skipVersionCheck[sub.Name] {
+			continue
+		}
+		sub.Flags = append(sub.Flags, &ufcli.BoolFlag{
+			Name:  "auto-switch",
+			Usage: "Switch to a terraform version matching required_version with tfenv or tfswitch",
+		})
+
+		before := sub.Before
+		sub.Before = func(c *ufcli.Context) error {
+			dir := c.String("path")
+			if dir == "" {
+				dir = "."
+			}
+			if err := ensureTerraformVersion(c.Context, dir, c.Bool("auto-switch")); err != nil {
+				return err
+			}
+			if before != nil {
+				return before(c)
+			}
+			return nil
+		}
+	}
+	return cmd
+}
+
+// ensureTerraformVersion warns when the installed terraform doesn't satisfy
+// the module's required_version, or switches to a matching version with
+// tfenv or tfswitch when autoSwitch is set. Modules without a
+// required_version are not checked.
+func ensureTerraformVersion(ctx context.Context, dir string, autoSwitch bool) error {
+	constraint, file, err := readRequiredVersion(dir)
+	if err != nil || constraint == "" {
+		return nil
+	}
+
+	installed, err := installedTerraformVersion(ctx)
+	if err != nil {
+		// A missing terraform is reported by the command itself
+		return nil
+	}
+	ok, err := versionSatisfies(installed, constraint)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not check required_version in %s: %v\n", file, err)
+		return nil
+	}
+	if ok {
+		return nil
+	}
+
+	switcher := versionSwitcher()
+	if !autoSwitch {
+		fmt.Fprintf(os.Stderr, "⚠️  terraform %s does not satisfy required_version %q (%s)\n", installed, constraint, file)
+		if switcher != "" {
+			fmt.Fprintf(os.Stderr, "   Re-run with --auto-switch to switch versions with %s\n", switcher)
+		} else {
+			fmt.Fprintln(os.Stderr, "   Install tfenv or tfswitch and re-run with --auto-switch to switch automatically")
+		}
+		return nil
+	}
+	if switcher == "" {
+		return fmt.Errorf("--auto-switch requires tfenv or tfswitch on the PATH")
+	}
+
+	fmt.Fprintf(os.Stderr, "Switching terraform to match %q (%s) with %s...\n", constraint, file, switcher)
+	if err := switchTerraformVersion(ctx, dir, switcher); err != nil {
+		return err
+	}
+
+	installed, err = installedTerraformVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read terraform version after switching: %w", err)
+	}
+	if ok, _ := versionSatisfies(installed, constraint); !ok {
+		return fmt.Errorf("%s switched to terraform %s, which still does not satisfy %q", switcher, installed, constraint)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Using terraform %s\n", installed)
+	return nil
+}
+
+// readRequiredVersion returns the first required_version constraint found in
+// the .tf files of dir, and the file it came from
+func readRequiredVersion(dir string) (string, string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return "", "", err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if match := requiredVersionPattern.FindSubmatch(data); match != nil {
+			return string(match[1]), filepath.Base(file), nil
+		}
+	}
+	return "", "", nil
+}
+
+// installedTerraformVersion returns the version of the terraform on the PATH
+func installedTerraformVersion(ctx context.Context) (string, error) {
+	output, err := shell.Run(ctx, "terraform", "version", "-json")
+	if err != nil {
+		return "", err
+	}
+
+	var version struct {
+		TerraformVersion string `json:"terraform_version"`
+	}
+	if err := json.Unmarshal([]byte(output), &version); err != nil || version.TerraformVersion == "" {
+		return "", fmt.Errorf("unexpected terraform version output: %s", output)
+	}
+	return version.TerraformVersion, nil
+}
+
+// versionSwitcher returns the installed version manager, preferring tfenv
+func versionSwitcher() string {
+	for _, tool := range []string{"tfenv", "tfswitch"} {
+		if shell.CommandExists(tool) {
+			return tool
+		}
+	}
+	return ""
+}
+
+// switchTerraformVersion activates the newest terraform allowed by dir's
+// required_version. Both tools read the constraint from the directory.
+func switchTerraformVersion(ctx context.Context, dir, switcher string) error {
+	if switcher == "tfswitch" {
+		if output, err := shell.RunWithDir(ctx, dir, "tfswitch"); err != nil {
+			return fmt.Errorf("tfswitch failed: %w\n%s", err, output)
+		}
+		return nil
+	}
+
+	for _, args := range [][]string{{"install", "latest-allowed"}, {"use", "latest-allowed"}} {
+		if output, err := shell.RunWithDir(ctx, dir, "tfenv", args...); err != nil {
+			return fmt.Errorf("tfenv %s failed: %w\n%s", args[0], err, output)
+		}
+	}
+	return nil
+}
+
+// versionSatisfies reports whether version meets every comma-separated
+// constraint, following Terraform's operators including ~>
+func versionSatisfies(version, constraints string) (bool, error) {
+	v, _, err := parseVersion(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return false, err
+	}
+
+	for _, constraint := range strings.Split(constraints, ",") {
+		match := versionPartsPattern.FindStringSubmatch(strings.TrimSpace(constraint))
+		if match == nil {
+			return false, fmt.Errorf("malformed version constraint %q", constraint)
+		}
+		want, segments, err := parseVersion(match[2])
+		if err != nil {
+			return false, err
+		}
+
+		cmp := compareVersions(v, want)
+		var ok bool
+		switch match[1] {
+		case "", "=":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case "~>":
+			// ~> 1.5 allows >= 1.5, < 2.0; ~> 1.5.0 allows >= 1.5.0, < 1.6.0
+			ok = cmp >= 0 && compareVersions(v, pessimisticUpperBound(want, segments)) < 0
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// parseVersion parses major[.minor[.patch]], ignoring any prerelease suffix,
+// and returns the number of segments given
+func parseVersion(version string) ([3]int, int, error) {
+	var v [3]int
+	version, _, _ = strings.Cut(version, "-")
+	parts := strings.Split(version, ".")
+	if len(parts) > 3 {
+		return v, 0, fmt.Errorf("malformed version %q", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return v, 0, fmt.Errorf("malformed version %q", version)
+		}
+		v[i] = n
+	}
+	return v, len(parts), nil
+}
+
+// compareVersions compares two parsed versions segment by segment
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
+
+// pessimisticUpperBound returns the exclusive upper bound of ~> v, which
+// bumps the second-to-last given segment
+func pessimisticUpperBound(v [3]int, segments int) [3]int {
+	if segments < 2 {
+		return [3]int{v[0] + 1, 0, 0}
+	}
+	var upper [3]int
+	copy(upper[:], v[:segments-2])
+	upper[segments-2] = v[segments-2] + 1
+	return upper
+}